@@ -0,0 +1,161 @@
+// The catalog generator turns the action catalog snapshot in
+// cmd/action_catalog.json into the Go code of sampolicies/actions.go. It only
+// reads the snapshot and never fetches anything.
+//
+// The snapshot is a hand-curated subset of the "Actions, resources, and
+// condition keys" pages of the AWS Service Authorization Reference, which the
+// Source of the snapshot describes. It isn't generated from an export, so it
+// has no scrape date. To refresh it, check the access level, resource types,
+// and condition keys of the actions in the snapshot against the page of their
+// service, edit the snapshot, and run go generate ./sampolicies. The
+// generator fails if the snapshot has no Source.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+const (
+	// generatedHeader marks the output as generated code
+	generatedHeader = "// Code generated by catalog-generator. DO NOT EDIT.\n\n"
+)
+
+// accessLevels maps the access levels in the catalog to the AccessLevel
+// constants of the sampolicies package
+var accessLevels = map[string]string{
+	"List":                   "AccessList",
+	"Read":                   "AccessRead",
+	"Write":                  "AccessWrite",
+	"Permissions management": "AccessPermissionsManagement",
+	"Tagging":                "AccessTagging",
+}
+
+// catalog is the format of the action catalog snapshot
+type catalog struct {
+	Version  string             `json:"Version"`
+	Source   string             `json:"Source"`
+	Services map[string]service `json:"Services"`
+}
+
+// service is a single AWS service in the action catalog
+type service struct {
+	Name    string            `json:"Name"`
+	Actions map[string]action `json:"Actions"`
+}
+
+// action is a single action of a service in the action catalog
+type action struct {
+	AccessLevel   string   `json:"AccessLevel"`
+	ResourceTypes []string `json:"ResourceTypes"`
+	ConditionKeys []string `json:"ConditionKeys"`
+}
+
+func main() {
+	input := flag.String("input", "../cmd/action_catalog.json", "action catalog to generate the Go code from")
+	output := flag.String("output", "../sampolicies/actions.go", "file to write the generated catalog to")
+	pkg := flag.String("package", "sampolicies", "package name of the generated file")
+	flag.Parse()
+
+	body, err := ioutil.ReadFile(*input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var c catalog
+	if err := json.Unmarshal(body, &c); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to parse action catalog: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if len(c.Source) == 0 {
+		fmt.Fprintln(os.Stderr, "action catalog must have a Source")
+		os.Exit(1)
+	}
+
+	// Sort the services and actions so every run generates the same file
+	prefixes := make([]string, 0, len(c.Services))
+	for prefix := range c.Services {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+
+	var services, actions bytes.Buffer
+
+	for _, prefix := range prefixes {
+		s := c.Services[prefix]
+		services.WriteString(fmt.Sprintf("%q: %q,\n", prefix, s.Name))
+
+		names := make([]string, 0, len(s.Actions))
+		for name := range s.Actions {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			a, err := getAction(prefix, name, s.Actions[name])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s:%s: %s\n", prefix, name, err.Error())
+				os.Exit(1)
+			}
+			actions.WriteString(a)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(generatedHeader)
+	buf.WriteString(fmt.Sprintf("package %s\n\n", *pkg))
+	buf.WriteString(fmt.Sprintf("// Source of the action catalog: %s.\n\n", c.Source))
+	buf.WriteString(fmt.Sprintf("// catalogServices maps the prefix of the services in the action catalog to\n// their name\nvar catalogServices = map[string]string{\n%s}\n\n", services.String()))
+	buf.WriteString(fmt.Sprintf("// catalogActions are the actions in the action catalog, sorted by service\n// and name\nvar catalogActions = []ActionInfo{\n%s}\n", actions.String()))
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "unable to format generated code: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// getAction generates the ActionInfo literal for a single action
+func getAction(prefix string, name string, a action) (string, error) {
+	level, ok := accessLevels[a.AccessLevel]
+	if !ok {
+		return "", fmt.Errorf("unknown access level %q in getAction", a.AccessLevel)
+	}
+
+	fields := []string{
+		fmt.Sprintf("Service: %q", prefix),
+		fmt.Sprintf("Name: %q", name),
+		fmt.Sprintf("AccessLevel: %s", level),
+	}
+	if len(a.ResourceTypes) > 0 {
+		fields = append(fields, fmt.Sprintf("ResourceTypes: %s", getStrings(a.ResourceTypes)))
+	}
+	if len(a.ConditionKeys) > 0 {
+		fields = append(fields, fmt.Sprintf("ConditionKeys: %s", getStrings(a.ConditionKeys)))
+	}
+
+	return fmt.Sprintf("{%s},\n", strings.Join(fields, ", ")), nil
+}
+
+// getStrings returns the []string literal for the strings
+func getStrings(values []string) string {
+	quoted := make([]string, len(values))
+	for idx := range values {
+		quoted[idx] = fmt.Sprintf("%q", values[idx])
+	}
+	return fmt.Sprintf("[]string{%s}", strings.Join(quoted, ", "))
+}
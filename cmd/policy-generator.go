@@ -1,142 +1,452 @@
 package main
 
 import (
+	"bytes"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"go/format"
+	"go/token"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
 )
 
+const (
+	// templatesURL is the location of the policy templates in the Serverless Application Model repository
+	templatesURL = "https://raw.githubusercontent.com/awslabs/serverless-application-model/develop/samtranslator/policy_templates_data/policy_templates.json"
+
+	// generatedHeader marks the output as generated code
+	generatedHeader = "// Code generated by policy-generator. DO NOT EDIT.\n\n"
+
+	// pulumiImport is the import path of the Pulumi SDK used by the InputFactory methods
+	pulumiImport = "github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
 func main() {
-	// Get the policy templates from the Serverless Application Repository
-	url := "https://raw.githubusercontent.com/awslabs/serverless-application-model/develop/samtranslator/policy_templates_data/policy_templates.json"
+	input := flag.String("input", "", "local policy_templates.json to read instead of downloading it from GitHub")
+	output := flag.String("output", "../sampolicies/policies.go", "file to write the generated policies to")
+	pkg := flag.String("package", "sampolicies", "package name of the generated file")
+	flag.Parse()
 
-	req, err := http.NewRequest("GET", url, nil)
+	body, err := getTemplates(*input)
 	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	var policies map[string]interface{}
+	if err := json.Unmarshal(body, &policies); err != nil {
+		fmt.Fprintf(os.Stderr, "unable to parse policy templates: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	templates, ok := policies["Templates"].(map[string]interface{})
+	if !ok {
+		fmt.Fprintln(os.Stderr, "policy templates have no Templates element")
+		os.Exit(1)
+	}
+
+	// Sort the templates so every run generates the same file
+	names := make([]string, 0, len(templates))
+	for key := range templates {
+		names = append(names, key)
+	}
+	sort.Strings(names)
+
+	errPolicies := make([]string, 0)
+
+	var data, methods bytes.Buffer
+
+	for _, name := range names {
+		template, method, err := getPolicy(name, templates[name])
+		if err != nil {
+			errPolicies = append(errPolicies, fmt.Sprintf("%s: %s", name, err.Error()))
+			continue
+		}
+		data.WriteString(template)
+		methods.WriteString(method)
 	}
 
-	res, err := http.DefaultClient.Do(req)
+	var buf bytes.Buffer
+	buf.WriteString(generatedHeader)
+	buf.WriteString(fmt.Sprintf("package %s\n\n", *pkg))
+	buf.WriteString(fmt.Sprintf("import %q\n\n", pulumiImport))
+	buf.WriteString(fmt.Sprintf("// samTemplates are the AWS SAM policy templates\nvar samTemplates = []Template{\n%s}\n\n", data.String()))
+	buf.WriteString(methods.String())
+
+	src, err := format.Source(buf.Bytes())
 	if err != nil {
-		panic(err)
+		fmt.Fprintf(os.Stderr, "unable to format generated code: %s\n", err.Error())
+		os.Exit(1)
 	}
 
-	defer res.Body.Close()
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if len(errPolicies) > 0 {
+		fmt.Fprintf(os.Stderr, "There are %d policies that encountered errors:\n", len(errPolicies))
+		for idx := range errPolicies {
+			fmt.Fprintln(os.Stderr, errPolicies[idx])
+		}
+		os.Exit(1)
+	}
+}
 
-	body, err := ioutil.ReadAll(res.Body)
+// getTemplates reads the policy templates from the input file or, if no file
+// is given, downloads them from GitHub.
+func getTemplates(input string) ([]byte, error) {
+	if len(input) > 0 {
+		return ioutil.ReadFile(input)
+	}
+
+	res, err := http.Get(templatesURL)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	var policies map[string]interface{}
-	json.Unmarshal(body, &policies)
+	defer res.Body.Close()
 
-	errPolicies := make([]string, 0)
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to download policy templates: %s", res.Status)
+	}
+
+	return ioutil.ReadAll(res.Body)
+}
+
+// getPolicy generates the Template literal and the Add methods of the
+// Factory and the InputFactory for a single template.
+func getPolicy(name string, p interface{}) (string, string, error) {
+	pt, ok := p.(map[string]interface{})
+	if !ok {
+		return "", "", fmt.Errorf("unknown template type %T in getPolicy", p)
+	}
+
+	description, _ := pt["Description"].(string)
 
-	f, err := os.Create("../policies.go")
+	d, ok := pt["Definition"].(map[string]interface{})
+	if !ok {
+		return "", "", fmt.Errorf("template has no Definition in getPolicy")
+	}
+
+	s, ok := d["Statement"].([]interface{})
+	if !ok {
+		return "", "", fmt.Errorf("template has no Statement list in getPolicy")
+	}
+
+	statements, params, err := getPolicyStatements(s)
 	if err != nil {
-		panic(err)
+		return "", "", err
+	}
+
+	parameters, _ := pt["Parameters"].(map[string]interface{})
+
+	args := make([]string, len(params))
+	inputArgs := make([]string, len(params))
+	values := make([]string, len(params))
+	fields := make([]string, len(params))
+	for idx := range params {
+		args[idx] = fmt.Sprintf("%s string", getParameterName(params[idx]))
+		inputArgs[idx] = fmt.Sprintf("%s pulumi.StringInput", getParameterName(params[idx]))
+		values[idx] = fmt.Sprintf("%q: %s", params[idx], getParameterName(params[idx]))
+		fields[idx] = fmt.Sprintf("{Name: %q, Description: %q},", params[idx], getParameterDescription(parameters, params[idx]))
 	}
 
-	f.WriteString(fmt.Sprintf("package policytemplates\n\nimport \"strings\"\n\n"))
+	paramMap, inputParamMap := "nil", "nil"
+	if len(params) > 0 {
+		paramMap = fmt.Sprintf("map[string]string{%s}", strings.Join(values, ", "))
+		inputParamMap = fmt.Sprintf("map[string]pulumi.StringInput{%s}", strings.Join(values, ", "))
+	}
+
+	template := fmt.Sprintf("{\nName: %q,\nDescription: %q,\n", name, description)
+	if len(params) > 0 {
+		template += fmt.Sprintf("Parameters: []Parameter{\n%s\n},\n", strings.Join(fields, "\n"))
+	}
+	template += fmt.Sprintf("Statements: []Statement{\n%s,\n},\n},\n", strings.Join(statements, ",\n"))
+
+	method := fmt.Sprintf("// Add%s %s\nfunc(f *Factory) Add%s(%s) {\nf.mustAdd(%q, %s)\n}\n\n", name, description, name, strings.Join(args, ", "), name, paramMap)
+	method += fmt.Sprintf("// Add%s %s\nfunc(f *InputFactory) Add%s(%s) {\nf.mustAdd(%q, %s)\n}\n\n", name, description, name, strings.Join(inputArgs, ", "), name, inputParamMap)
+
+	return template, method, nil
+}
+
+// getParameterDescription returns the description of a template parameter
+func getParameterDescription(parameters map[string]interface{}, name string) string {
+	p, _ := parameters[name].(map[string]interface{})
+	description, _ := p["Description"].(string)
+	return description
+}
+
+// statementKeys are the elements of a template statement, in the order they're
+// written to the Statement literal
+var statementKeys = []string{"Sid", "Effect", "Action", "NotAction", "Resource", "NotResource", "Condition"}
 
-	for key, val := range policies["Templates"].(map[string]interface{}) {
-		pt := val.(map[string]interface{})
+// placeholderRegexp matches the ${Name} placeholders in a resolved string
+var placeholderRegexp = regexp.MustCompile(`\$\{([^}]+)\}`)
 
-		name := key
-		description := pt["Description"].(string)
+// getPolicyStatements translates every statement of a template into a
+// Statement literal and returns them together with the parameters the
+// statements use, in the order they first appear.
+func getPolicyStatements(p []interface{}) ([]string, []string, error) {
+	statements := make([]string, len(p))
+	params := make([]string, 0)
+	seen := make(map[string]bool)
 
-		d := pt["Definition"].(map[string]interface{})
-		s := d["Statement"].([]interface{})
-		effect, action, resource, param, err := getPolicyStatement(s)
+	for idx := range p {
+		statement, err := getPolicyStatement(p[idx])
 		if err != nil {
-			errPolicies = append(errPolicies, name)
-			f.WriteString(fmt.Sprintf("// Add%s %s\nfunc(f *Factory) Add%s() string {return ``}\n\n", name, description, name))
-			continue
+			return nil, nil, err
 		}
-		action = strings.ReplaceAll(action, ",", "\",\"")
-		returnString := "f.policies = append(f.policies, policy)"
-		if len(param) > 1 {
-			returnString = fmt.Sprintf("policy = strings.ReplaceAll(policy, \"${%s}\", %s)\n%s", param, param, returnString)
-			param = fmt.Sprintf("%s string", param)
+		statements[idx] = statement
+
+		for _, match := range placeholderRegexp.FindAllStringSubmatch(statement, -1) {
+			if strings.HasPrefix(match[1], "AWS::") || seen[match[1]] {
+				continue
+			}
+			seen[match[1]] = true
+			params = append(params, match[1])
 		}
-		f.WriteString(fmt.Sprintf("// Add%s %s\nfunc(f *Factory) Add%s(%s) {\npolicy := `{ \"Action\": [\"%s\"], \"Effect\": \"%s\", \"Resource\": \"%s\" }`\n%s\n}\n\n", name, description, name, param, action, effect, resource, returnString))
 	}
 
-	if len(errPolicies) > 0 {
-		fmt.Printf("\n\nThere are %d policies that encountered errors:\n", len(errPolicies))
-		for idx := range errPolicies {
-			fmt.Println(errPolicies[idx])
+	return statements, params, nil
+}
+
+// getPolicyStatement translates a single template statement into a Statement
+// literal. Template parameters are written as ${ParameterName} placeholders.
+func getPolicyStatement(p interface{}) (string, error) {
+	policy, ok := p.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("unknown statement type %T in getPolicyStatement", p)
+	}
+
+	known := make(map[string]bool)
+	for _, key := range statementKeys {
+		known[key] = true
+	}
+	for key := range policy {
+		if !known[key] {
+			return "", fmt.Errorf("unknown statement element %s in getPolicyStatement", key)
 		}
 	}
+
+	fields := make([]string, 0)
+	for _, key := range statementKeys {
+		val, ok := policy[key]
+		if !ok {
+			continue
+		}
+
+		switch key {
+		case "Sid", "Effect":
+			s, err := resolveString(val)
+			if err != nil {
+				return "", err
+			}
+			fields = append(fields, fmt.Sprintf("%s: %q,", key, s))
+		case "Condition":
+			condition, err := getCondition(val)
+			if err != nil {
+				return "", err
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s,", key, condition))
+		default:
+			values, err := resolve(val)
+			if err != nil {
+				return "", err
+			}
+			fields = append(fields, fmt.Sprintf("%s: %s,", key, getValue(values)))
+		}
+	}
+
+	return fmt.Sprintf("{\n%s\n}", strings.Join(fields, "\n")), nil
 }
 
-func getPolicyStatement(p []interface{}) (string, string, string, string, error) {
-	var err error
+// getCondition translates a condition block into a Condition literal with
+// the operators and keys sorted.
+func getCondition(p interface{}) (string, error) {
+	condition, ok := p.(map[string]interface{})
+	if !ok {
+		return "", fmt.Errorf("unknown condition type %T in getCondition", p)
+	}
 
-	policy := p[0].(map[string]interface{})
+	operators := make([]string, 0, len(condition))
+	for operator := range condition {
+		operators = append(operators, operator)
+	}
+	sort.Strings(operators)
 
-	effect := policy["Effect"].(string)
+	blocks := make([]string, len(operators))
+	for idx, operator := range operators {
+		keys, ok := condition[operator].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("unknown condition operator type %T in getCondition", condition[operator])
+		}
 
-	action := ""
-	switch v := policy["Action"].(type) {
-	case string:
-		action = policy["Action"].(string)
-	case []interface{}:
-		a := policy["Action"].([]interface{})
-		actions := make([]string, len(a))
-		for idx := range actions {
-			actions[idx] = a[idx].(string)
+		names := make([]string, 0, len(keys))
+		for name := range keys {
+			names = append(names, name)
 		}
-		action = strings.Join(actions, ",")
-	default:
-		err = fmt.Errorf("unknown action type %T in getPolicyStatement", v)
+		sort.Strings(names)
+
+		values := make([]string, len(names))
+		for i, name := range names {
+			v, err := resolve(keys[name])
+			if err != nil {
+				return "", err
+			}
+			values[i] = fmt.Sprintf("%q: %s,", name, getValue(v))
+		}
+		blocks[idx] = fmt.Sprintf("%q: {\n%s\n},", operator, strings.Join(values, "\n"))
+	}
+
+	return fmt.Sprintf("Condition{\n%s\n}", strings.Join(blocks, "\n")), nil
+}
+
+// getValue returns the Value literal for the strings
+func getValue(values []string) string {
+	quoted := make([]string, len(values))
+	for idx := range values {
+		quoted[idx] = fmt.Sprintf("%q", values[idx])
 	}
+	return fmt.Sprintf("Value{%s}", strings.Join(quoted, ", "))
+}
 
-	resource := ""
-	param := ""
-	switch v := policy["Resource"].(type) {
+// resolve evaluates the intrinsic functions in a template value and returns
+// the resulting list of strings. The supported intrinsic functions are Ref
+// (including AWS::NoValue and the AWS pseudo parameters), Fn::Sub, and
+// Fn::Join. Policy templates don't have a Conditions section, so an Fn::If
+// can't be evaluated and results in an error rather than a guessed value.
+func resolve(p interface{}) ([]string, error) {
+	switch v := p.(type) {
 	case string:
-		resource = policy["Resource"].(string)
+		return []string{v}, nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for idx := range v {
+			r, err := resolve(v[idx])
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, r...)
+		}
+		return values, nil
 	case map[string]interface{}:
-		res := policy["Resource"].(map[string]interface{})
-		resource, param, err = getResource(res["Fn::Sub"])
-	default:
-		err = fmt.Errorf("unknown resource type %T in getPolicyStatement", v)
+		if len(v) != 1 {
+			return nil, fmt.Errorf("unknown intrinsic function with %d keys in resolve", len(v))
+		}
+		for fn, args := range v {
+			switch fn {
+			case "Ref":
+				return resolveRef(args)
+			case "Fn::Sub":
+				s, err := resolveSub(args)
+				if err != nil {
+					return nil, err
+				}
+				return []string{s}, nil
+			case "Fn::Join":
+				s, err := resolveJoin(args)
+				if err != nil {
+					return nil, err
+				}
+				return []string{s}, nil
+			case "Fn::If":
+				return nil, fmt.Errorf("Fn::If can't be evaluated without the Conditions of a template in resolve")
+			default:
+				return nil, fmt.Errorf("unknown intrinsic function %s in resolve", fn)
+			}
+		}
 	}
+	return nil, fmt.Errorf("unknown value type %T in resolve", p)
+}
 
-	return effect, action, resource, param, err
+// resolveString resolves a value that must result in exactly one string
+func resolveString(p interface{}) (string, error) {
+	values, err := resolve(p)
+	if err != nil {
+		return "", err
+	}
+	if len(values) != 1 {
+		return "", fmt.Errorf("value resolves to %d strings instead of one in resolveString", len(values))
+	}
+	return values[0], nil
 }
 
-func getResource(p interface{}) (string, string, error) {
+// resolveRef returns the placeholder for a parameter or pseudo parameter, or
+// no value at all for AWS::NoValue.
+func resolveRef(p interface{}) ([]string, error) {
+	name, ok := p.(string)
+	if !ok {
+		return nil, fmt.Errorf("unknown Ref type %T in resolveRef", p)
+	}
+	if name == "AWS::NoValue" {
+		return []string{}, nil
+	}
+	return []string{fmt.Sprintf("${%s}", name)}, nil
+}
+
+// resolveSub substitutes the variables of an Fn::Sub. Variables that aren't
+// in the variable map are pseudo parameters and are left as placeholders.
+func resolveSub(p interface{}) (string, error) {
 	switch v := p.(type) {
 	case string:
-		return p.(string), "", nil
+		return strings.ReplaceAll(v, "${!", "${"), nil
 	case []interface{}:
-		a := p.([]interface{})
-		param := getParameter(a[1].(map[string]interface{}))
-		return a[0].(string), param, nil
+		if len(v) != 2 {
+			return "", fmt.Errorf("Fn::Sub needs a string and a variable map in resolveSub")
+		}
+		s, ok := v[0].(string)
+		if !ok {
+			return "", fmt.Errorf("unknown Fn::Sub string type %T in resolveSub", v[0])
+		}
+		vars, ok := v[1].(map[string]interface{})
+		if !ok {
+			return "", fmt.Errorf("unknown Fn::Sub variable map type %T in resolveSub", v[1])
+		}
+
+		oldnew := make([]string, 0, len(vars)*2)
+		for key, val := range vars {
+			r, err := resolveString(val)
+			if err != nil {
+				return "", err
+			}
+			oldnew = append(oldnew, fmt.Sprintf("${%s}", key), r)
+		}
+		s = strings.NewReplacer(oldnew...).Replace(s)
+		return strings.ReplaceAll(s, "${!", "${"), nil
 	default:
-		return "", "", fmt.Errorf("unknown resource type %T in getResource", v)
+		return "", fmt.Errorf("unknown Fn::Sub type %T in resolveSub", p)
 	}
 }
 
-func getParameter(p map[string]interface{}) string {
-	parameters := make([]string, 0)
-	for key := range p {
-		parameters = append(parameters, key)
+// resolveJoin joins the resolved values of an Fn::Join with its delimiter
+func resolveJoin(p interface{}) (string, error) {
+	v, ok := p.([]interface{})
+	if !ok || len(v) != 2 {
+		return "", fmt.Errorf("Fn::Join needs a delimiter and a list of values in resolveJoin")
 	}
-
-	var parameterString string
-
-	switch len(parameters) {
-	case 0:
-		parameterString = ""
-	case 1:
-		parameterString = parameters[0]
+	delimiter, ok := v[0].(string)
+	if !ok {
+		return "", fmt.Errorf("unknown Fn::Join delimiter type %T in resolveJoin", v[0])
+	}
+	values, err := resolve(v[1])
+	if err != nil {
+		return "", err
 	}
+	return strings.Join(values, delimiter), nil
+}
 
-	return parameterString
+// getParameterName returns the Go name for a template parameter, which is
+// the parameter name starting with a lowercase letter.
+func getParameterName(p string) string {
+	name := strings.ToLower(p[:1]) + p[1:]
+	if token.IsKeyword(name) {
+		name = name + "Name"
+	}
+	return name
 }
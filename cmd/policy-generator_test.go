@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/retgits/pulumi-helpers/v2/sampolicies"
+)
+
+// readFixtures reads the templates in testdata/policy_templates.json
+func readFixtures(t *testing.T) map[string]interface{} {
+	body, err := getTemplates("testdata/policy_templates.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		t.Fatal(err)
+	}
+	return data["Templates"].(map[string]interface{})
+}
+
+func TestGetPolicyIntrinsicFunctions(t *testing.T) {
+	templates := readFixtures(t)
+
+	tests := []struct {
+		name     string
+		resource string
+	}{
+		{"JoinPolicy", `Resource: Value{"arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${QueueName}"}`},
+		{"NoValuePolicy", `Resource: Value{"arn:${AWS::Partition}:s3:::${BucketName}/*"}`},
+	}
+
+	for _, tt := range tests {
+		template, method, err := getPolicy(tt.name, templates[tt.name])
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if !strings.Contains(template, tt.resource) {
+			t.Errorf("%s: template doesn't contain %s:\n%s", tt.name, tt.resource, template)
+		}
+		if !strings.Contains(method, "func(f *Factory) Add"+tt.name+"(") {
+			t.Errorf("%s: missing Add method:\n%s", tt.name, method)
+		}
+	}
+}
+
+func TestGetPolicyIfFails(t *testing.T) {
+	templates := readFixtures(t)
+
+	if _, _, err := getPolicy("IfPolicy", templates["IfPolicy"]); err == nil || !strings.Contains(err.Error(), "Fn::If") {
+		t.Errorf("got error %v, want an error for Fn::If", err)
+	}
+}
+
+// TestParameterNamesMatchSearch pins getParameterName to the Go names that
+// sampolicies uses in the usage of the templates it finds, so the names in
+// the search results are the names of the generated method arguments.
+func TestParameterNamesMatchSearch(t *testing.T) {
+	f := sampolicies.NewFactory()
+	custom := sampolicies.Template{
+		Name:       "KeywordPolicy",
+		Parameters: []sampolicies.Parameter{{Name: "Type"}, {Name: "Func"}, {Name: "URL"}},
+		Statements: []sampolicies.Statement{{
+			Effect:   sampolicies.EffectAllow,
+			Action:   sampolicies.Value{"sqs:SendMessage"},
+			Resource: sampolicies.Value{"arn:${AWS::Partition}:sqs:${AWS::Region}:${Type}:${Func}-${URL}"},
+		}},
+	}
+	if err := f.RegisterTemplate(custom); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tmpl := range f.Templates() {
+		params := make([]string, len(tmpl.Parameters))
+		for idx, p := range tmpl.Parameters {
+			params[idx] = getParameterName(p.Name)
+		}
+
+		want := fmt.Sprintf("Add%s(%s)", tmpl.Name, strings.Join(params, ", "))
+		if tmpl.Name == custom.Name {
+			for idx, p := range tmpl.Parameters {
+				params[idx] = fmt.Sprintf("%q: %s", p.Name, params[idx])
+			}
+			want = fmt.Sprintf("Add(%q, map[string]string{%s})", tmpl.Name, strings.Join(params, ", "))
+		}
+
+		if got := (sampolicies.TemplateMatch{Template: tmpl}).Usage(); got != want {
+			t.Errorf("%s: got %s, want %s", tmpl.Name, got, want)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/retgits/pulumi-helpers/v2/sampolicies"
+)
+
+const usage = `policy-tool works with AWS IAM policy documents.
+
+Usage:
+  policy-tool cloudtrail -role <arn> -account <id> -region <region> [-partition <partition>] [-policy <file>] <log file>...
+  policy-tool diff <old policy file> <new policy file>
+  policy-tool search [-resource <arn>] <action>
+`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprint(os.Stderr, usage)
+	}
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var status int
+	var err error
+	switch flag.Arg(0) {
+	case "cloudtrail":
+		status, err = cloudtrail(flag.Args()[1:])
+	case "diff":
+		status, err = diff(flag.Args()[1:])
+	case "search":
+		status, err = search(flag.Args()[1:])
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	os.Exit(status)
+}
+
+// diff prints the semantic changes between two policy documents and returns
+// exit status 1 if there are changes, like diff does.
+func diff(args []string) (int, error) {
+	fs := flag.NewFlagSet("diff", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: policy-tool diff <old policy file> <new policy file>")
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	oldPolicy, err := ioutil.ReadFile(fs.Arg(0))
+	if err != nil {
+		return 0, err
+	}
+
+	newPolicy, err := ioutil.ReadFile(fs.Arg(1))
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := sampolicies.DiffPolicies(string(oldPolicy), string(newPolicy))
+	if err != nil {
+		return 0, err
+	}
+
+	if result.Empty() {
+		return 0, nil
+	}
+
+	fmt.Println(result)
+	return 1, nil
+}
+
+// search prints the policy templates that grant the action, from the most to
+// the least narrow, and returns exit status 1 if no template grants it.
+func search(args []string) (int, error) {
+	fs := flag.NewFlagSet("search", flag.ExitOnError)
+	resource := fs.String("resource", "", "ARN of the resource the action must be granted on")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: policy-tool search [-resource <arn>] <action>")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	matches := sampolicies.FindTemplates(fs.Arg(0), *resource)
+	if len(matches) == 0 {
+		fmt.Fprintf(os.Stderr, "no template grants %s\n", fs.Arg(0))
+		return 1, nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, m := range matches {
+		notes := make([]string, 0)
+		if m.WildcardResource {
+			notes = append(notes, "all resources")
+		}
+		if m.WildcardAction {
+			notes = append(notes, "wildcard action")
+		}
+		fmt.Fprintf(w, "%s\t%d actions\t%s\n", m.Usage(), m.Actions, strings.Join(notes, ", "))
+	}
+	return 0, w.Flush()
+}
+
+// cloudtrail prints the templates and custom statements that cover the calls
+// a role made in CloudTrail logs, and the permissions of the current policy
+// that weren't used. It returns exit status 1 if there are unused
+// permissions.
+func cloudtrail(args []string) (int, error) {
+	fs := flag.NewFlagSet("cloudtrail", flag.ExitOnError)
+	role := fs.String("role", "", "ARN of the role whose calls are read from the logs")
+	account := fs.String("account", "", "account ID of the role")
+	region := fs.String("region", "", "region of the role")
+	partition := fs.String("partition", "", "partition of the role, taken from the region if not set")
+	policy := fs.String("policy", "", "current policy document to find unused permissions in")
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: policy-tool cloudtrail -role <arn> -account <id> -region <region> [-partition <partition>] [-policy <file>] <log file>...")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	if len(*role) == 0 || fs.NArg() == 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	activity, err := sampolicies.ReadCloudTrail(*role, fs.Args()...)
+	if err != nil {
+		return 0, err
+	}
+
+	factory := sampolicies.NewFactory().WithAccountID(*account).WithRegion(*region)
+	if len(*partition) > 0 {
+		factory.WithPartition(*partition)
+	}
+
+	recommendation, err := factory.Recommend(activity)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(activity.Calls) > 0 {
+		fmt.Printf("Calls from %s to %s\n", activity.From.Format(time.RFC3339), activity.To.Format(time.RFC3339))
+	}
+
+	if len(recommendation.Templates) > 0 {
+		fmt.Println("\nTemplates:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, t := range recommendation.Templates {
+			fmt.Fprintf(w, "  %s\t%d calls\n", t.Usage(), len(t.Calls))
+		}
+		if err := w.Flush(); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(recommendation.Statements) > 0 {
+		b, err := json.MarshalIndent(recommendation.Statements, "", "  ")
+		if err != nil {
+			return 0, err
+		}
+		fmt.Printf("\nCustom statements:\n%s\n", string(b))
+	}
+
+	if len(activity.Denied) > 0 {
+		fmt.Println("\nDenied calls:")
+		for _, c := range activity.Denied {
+			fmt.Printf("  %s on %s (%d)\n", c.Action, c.Resource, c.Count)
+		}
+	}
+
+	if len(*policy) == 0 {
+		return 0, nil
+	}
+
+	doc, err := sampolicies.ReadPolicyDocument(*policy)
+	if err != nil {
+		return 0, err
+	}
+
+	unused := doc.UnusedPermissions(activity)
+	if len(unused) == 0 {
+		return 0, nil
+	}
+
+	fmt.Println("\nUnused permissions:")
+	for _, action := range unused {
+		fmt.Printf("  %s\n", action)
+	}
+	return 1, nil
+}
@@ -2,7 +2,7 @@ package main
 
 import (
 	// Import the builder package
-	"github.com/retgits/pulumi-helpers/builder"
+	"github.com/retgits/pulumi-helpers/v2/builder"
 )
 
 func main() {
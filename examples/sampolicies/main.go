@@ -3,7 +3,7 @@ package main
 import (
 	"fmt"
 	// Import the sampolicies package
-	"github.com/retgits/pulumi-helpers/sampolicies"
+	"github.com/retgits/pulumi-helpers/v2/sampolicies"
 )
 
 func main() {
@@ -23,7 +23,7 @@ func main() {
 	iamFactory.ClearPolicies()
 
 	// Add a new policy to the factory
-	iamFactory.AddAWSSecretsManagerRotationPolicy()
+	iamFactory.AddAWSSecretsManagerRotationPolicy("my-rotation-function")
 
 	// Create the policy document
 	policy, _ = iamFactory.GetPolicyStatement()
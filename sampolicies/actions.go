@@ -0,0 +1,674 @@
+// Code generated by catalog-generator. DO NOT EDIT.
+
+package sampolicies
+
+// Source of the action catalog: Hand-curated subset of the AWS Service Authorization Reference (https://docs.aws.amazon.com/service-authorization/latest/reference/reference_policies_actions-resources-contextkeys.html), not generated from an export and without a scrape date.
+
+// catalogServices maps the prefix of the services in the action catalog to
+// their name
+var catalogServices = map[string]string{
+	"athena":          "Amazon Athena",
+	"ce":              "AWS Cost Explorer Service",
+	"cloudformation":  "AWS CloudFormation",
+	"cloudwatch":      "Amazon CloudWatch",
+	"codecommit":      "AWS CodeCommit",
+	"codepipeline":    "AWS CodePipeline",
+	"comprehend":      "Amazon Comprehend",
+	"datapipeline":    "AWS Data Pipeline",
+	"dynamodb":        "Amazon DynamoDB",
+	"ec2":             "Amazon EC2",
+	"eks":             "Amazon Elastic Kubernetes Service",
+	"es":              "Amazon Elasticsearch Service",
+	"events":          "Amazon EventBridge",
+	"execute-api":     "Amazon API Gateway",
+	"firehose":        "Amazon Kinesis Firehose",
+	"glue":            "AWS Glue",
+	"iam":             "AWS Identity and Access Management",
+	"kinesis":         "Amazon Kinesis",
+	"kms":             "AWS Key Management Service",
+	"lambda":          "AWS Lambda",
+	"logs":            "Amazon CloudWatch Logs",
+	"mobileanalytics": "Amazon Mobile Analytics",
+	"mobiletargeting": "Amazon Pinpoint",
+	"organizations":   "AWS Organizations",
+	"polly":           "Amazon Polly",
+	"rekognition":     "Amazon Rekognition",
+	"s3":              "Amazon S3",
+	"secretsmanager":  "AWS Secrets Manager",
+	"serverlessrepo":  "AWS Serverless Application Repository",
+	"ses":             "Amazon SES",
+	"sns":             "Amazon SNS",
+	"sqs":             "Amazon SQS",
+	"ssm":             "AWS Systems Manager",
+	"states":          "AWS Step Functions",
+	"sts":             "AWS Security Token Service",
+	"textract":        "Amazon Textract",
+	"xray":            "AWS X-Ray",
+}
+
+// catalogActions are the actions in the action catalog, sorted by service
+// and name
+var catalogActions = []ActionInfo{
+	{Service: "athena", Name: "BatchGetQueryExecution", AccessLevel: AccessRead, ResourceTypes: []string{"workgroup"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "athena", Name: "CreateWorkGroup", AccessLevel: AccessWrite, ResourceTypes: []string{"workgroup"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "athena", Name: "DeleteWorkGroup", AccessLevel: AccessWrite, ResourceTypes: []string{"workgroup"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "athena", Name: "GetCatalogs", AccessLevel: AccessRead},
+	{Service: "athena", Name: "GetDataCatalog", AccessLevel: AccessRead, ResourceTypes: []string{"datacatalog"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "athena", Name: "GetExecutionEngine", AccessLevel: AccessRead},
+	{Service: "athena", Name: "GetExecutionEngines", AccessLevel: AccessRead},
+	{Service: "athena", Name: "GetNamespace", AccessLevel: AccessRead},
+	{Service: "athena", Name: "GetNamespaces", AccessLevel: AccessRead},
+	{Service: "athena", Name: "GetQueryExecution", AccessLevel: AccessRead, ResourceTypes: []string{"workgroup"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "athena", Name: "GetQueryResults", AccessLevel: AccessRead, ResourceTypes: []string{"workgroup"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "athena", Name: "GetTable", AccessLevel: AccessRead},
+	{Service: "athena", Name: "GetTables", AccessLevel: AccessRead},
+	{Service: "athena", Name: "GetWorkGroup", AccessLevel: AccessRead, ResourceTypes: []string{"workgroup"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "athena", Name: "ListDataCatalogs", AccessLevel: AccessList},
+	{Service: "athena", Name: "ListQueryExecutions", AccessLevel: AccessList, ResourceTypes: []string{"workgroup"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "athena", Name: "ListTagsForResource", AccessLevel: AccessRead, ResourceTypes: []string{"datacatalog", "workgroup"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "athena", Name: "ListWorkGroups", AccessLevel: AccessList},
+	{Service: "athena", Name: "StartQueryExecution", AccessLevel: AccessWrite, ResourceTypes: []string{"workgroup"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "athena", Name: "StopQueryExecution", AccessLevel: AccessWrite, ResourceTypes: []string{"workgroup"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "athena", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"datacatalog", "workgroup"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:ResourceTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "athena", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"datacatalog", "workgroup"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "ce", Name: "GetCostAndUsage", AccessLevel: AccessRead},
+	{Service: "ce", Name: "GetCostForecast", AccessLevel: AccessRead},
+	{Service: "ce", Name: "GetDimensionValues", AccessLevel: AccessRead},
+	{Service: "ce", Name: "GetReservationCoverage", AccessLevel: AccessRead},
+	{Service: "ce", Name: "GetReservationPurchaseRecommendation", AccessLevel: AccessRead},
+	{Service: "ce", Name: "GetReservationUtilization", AccessLevel: AccessRead},
+	{Service: "ce", Name: "GetSavingsPlansUtilization", AccessLevel: AccessRead},
+	{Service: "ce", Name: "GetTags", AccessLevel: AccessRead},
+	{Service: "cloudformation", Name: "CreateChangeSet", AccessLevel: AccessWrite, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "cloudformation:ChangeSetName", "cloudformation:ResourceTypes", "cloudformation:RoleArn", "cloudformation:StackPolicyUrl", "cloudformation:TemplateUrl"}},
+	{Service: "cloudformation", Name: "CreateStack", AccessLevel: AccessWrite, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "cloudformation:ResourceTypes", "cloudformation:RoleArn", "cloudformation:StackPolicyUrl", "cloudformation:TemplateUrl"}},
+	{Service: "cloudformation", Name: "DeleteStack", AccessLevel: AccessWrite, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "cloudformation:RoleArn"}},
+	{Service: "cloudformation", Name: "DescribeChangeSet", AccessLevel: AccessRead, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "cloudformation:ChangeSetName"}},
+	{Service: "cloudformation", Name: "DescribeStackEvents", AccessLevel: AccessRead, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "cloudformation", Name: "DescribeStackResource", AccessLevel: AccessRead, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "cloudformation", Name: "DescribeStackResources", AccessLevel: AccessRead, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "cloudformation", Name: "DescribeStacks", AccessLevel: AccessList, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "cloudformation", Name: "ExecuteChangeSet", AccessLevel: AccessWrite, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "cloudformation:ChangeSetName"}},
+	{Service: "cloudformation", Name: "GetTemplate", AccessLevel: AccessRead, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "cloudformation", Name: "ListStackResources", AccessLevel: AccessList, ResourceTypes: []string{"stack"}},
+	{Service: "cloudformation", Name: "ListStacks", AccessLevel: AccessList},
+	{Service: "cloudformation", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "cloudformation", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "cloudformation", Name: "UpdateStack", AccessLevel: AccessWrite, ResourceTypes: []string{"stack"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:ResourceTag/${TagKey}", "aws:TagKeys", "cloudformation:ResourceTypes", "cloudformation:RoleArn", "cloudformation:StackPolicyUrl", "cloudformation:TemplateUrl"}},
+	{Service: "cloudformation", Name: "ValidateTemplate", AccessLevel: AccessRead},
+	{Service: "cloudwatch", Name: "DeleteAlarms", AccessLevel: AccessWrite, ResourceTypes: []string{"alarm"}},
+	{Service: "cloudwatch", Name: "DeleteDashboards", AccessLevel: AccessWrite, ResourceTypes: []string{"dashboard"}},
+	{Service: "cloudwatch", Name: "DescribeAlarmHistory", AccessLevel: AccessRead, ResourceTypes: []string{"alarm"}},
+	{Service: "cloudwatch", Name: "DescribeAlarms", AccessLevel: AccessRead, ResourceTypes: []string{"alarm"}},
+	{Service: "cloudwatch", Name: "GetDashboard", AccessLevel: AccessRead, ResourceTypes: []string{"dashboard"}},
+	{Service: "cloudwatch", Name: "GetMetricData", AccessLevel: AccessRead},
+	{Service: "cloudwatch", Name: "GetMetricStatistics", AccessLevel: AccessRead},
+	{Service: "cloudwatch", Name: "ListDashboards", AccessLevel: AccessList},
+	{Service: "cloudwatch", Name: "ListMetrics", AccessLevel: AccessList},
+	{Service: "cloudwatch", Name: "ListTagsForResource", AccessLevel: AccessRead, ResourceTypes: []string{"alarm", "insight-rule"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "cloudwatch", Name: "PutDashboard", AccessLevel: AccessWrite, ResourceTypes: []string{"dashboard"}},
+	{Service: "cloudwatch", Name: "PutMetricAlarm", AccessLevel: AccessWrite, ResourceTypes: []string{"alarm"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "cloudwatch", Name: "PutMetricData", AccessLevel: AccessWrite, ConditionKeys: []string{"cloudwatch:namespace"}},
+	{Service: "cloudwatch", Name: "SetAlarmState", AccessLevel: AccessWrite, ResourceTypes: []string{"alarm"}},
+	{Service: "cloudwatch", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"alarm", "insight-rule"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:ResourceTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "cloudwatch", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"alarm", "insight-rule"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "codecommit", Name: "BatchDescribeMergeConflicts", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "BatchGetCommits", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "BatchGetPullRequests", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "CancelUploadArchive", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "CreateBranch", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "CreateCommit", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "CreatePullRequest", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "CreateRepository", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "codecommit", Name: "CreateUnreferencedMergeCommit", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "DeleteBranch", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "DeleteCommentContent", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "DeleteFile", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "DeleteRepository", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "DescribeMergeConflicts", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "DescribePullRequestEvents", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetBlob", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetBranch", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetComment", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetCommentsForComparedCommit", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetCommentsForPullRequest", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetCommit", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetCommitHistory", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetCommitsFromMergeBase", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetDifferences", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetFile", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetFolder", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetMergeCommit", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetMergeConflicts", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetMergeOptions", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetObjectIdentifier", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetPullRequest", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetReferences", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetRepository", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetRepositoryTriggers", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetTree", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GetUploadArchiveStatus", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GitPull", AccessLevel: AccessRead, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "GitPush", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "ListBranches", AccessLevel: AccessList, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "ListPullRequests", AccessLevel: AccessList, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "ListRepositories", AccessLevel: AccessList},
+	{Service: "codecommit", Name: "ListTagsForResource", AccessLevel: AccessList, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "MergeBranchesByFastForward", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "MergeBranchesBySquash", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "MergeBranchesByThreeWay", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "MergePullRequestByFastForward", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "MergePullRequestBySquash", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "MergePullRequestByThreeWay", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "PostCommentForComparedCommit", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "PostCommentForPullRequest", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "PostCommentReply", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "PutFile", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "codecommit:References"}},
+	{Service: "codecommit", Name: "PutRepositoryTriggers", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:ResourceTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "codecommit", Name: "TestRepositoryTriggers", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "codecommit", Name: "UpdateComment", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "UpdateDefaultBranch", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "UpdatePullRequestDescription", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "UpdatePullRequestStatus", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "UpdatePullRequestTitle", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "UpdateRepositoryDescription", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codecommit", Name: "UploadArchive", AccessLevel: AccessWrite, ResourceTypes: []string{"repository"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "codepipeline", Name: "GetPipeline", AccessLevel: AccessRead, ResourceTypes: []string{"pipeline"}},
+	{Service: "codepipeline", Name: "GetPipelineExecution", AccessLevel: AccessRead, ResourceTypes: []string{"pipeline"}},
+	{Service: "codepipeline", Name: "GetPipelineState", AccessLevel: AccessRead, ResourceTypes: []string{"pipeline"}},
+	{Service: "codepipeline", Name: "ListPipelineExecutions", AccessLevel: AccessList, ResourceTypes: []string{"pipeline"}},
+	{Service: "codepipeline", Name: "ListPipelines", AccessLevel: AccessList},
+	{Service: "codepipeline", Name: "PutJobFailureResult", AccessLevel: AccessWrite},
+	{Service: "codepipeline", Name: "PutJobSuccessResult", AccessLevel: AccessWrite},
+	{Service: "codepipeline", Name: "StartPipelineExecution", AccessLevel: AccessWrite, ResourceTypes: []string{"pipeline"}},
+	{Service: "comprehend", Name: "BatchDetectDominantLanguage", AccessLevel: AccessRead},
+	{Service: "comprehend", Name: "BatchDetectEntities", AccessLevel: AccessRead},
+	{Service: "comprehend", Name: "BatchDetectKeyPhrases", AccessLevel: AccessRead},
+	{Service: "comprehend", Name: "BatchDetectSentiment", AccessLevel: AccessRead},
+	{Service: "comprehend", Name: "ClassifyDocument", AccessLevel: AccessRead, ResourceTypes: []string{"document-classifier-endpoint"}},
+	{Service: "comprehend", Name: "DetectDominantLanguage", AccessLevel: AccessRead},
+	{Service: "comprehend", Name: "DetectEntities", AccessLevel: AccessRead},
+	{Service: "comprehend", Name: "DetectKeyPhrases", AccessLevel: AccessRead},
+	{Service: "comprehend", Name: "DetectPiiEntities", AccessLevel: AccessRead},
+	{Service: "comprehend", Name: "DetectSentiment", AccessLevel: AccessRead},
+	{Service: "datapipeline", Name: "ActivatePipeline", AccessLevel: AccessWrite, ConditionKeys: []string{"datapipeline:PipelineCreator", "datapipeline:Tag", "datapipeline:workerGroup"}},
+	{Service: "datapipeline", Name: "CreatePipeline", AccessLevel: AccessWrite, ConditionKeys: []string{"datapipeline:Tag"}},
+	{Service: "datapipeline", Name: "DescribePipelines", AccessLevel: AccessRead, ConditionKeys: []string{"datapipeline:PipelineCreator", "datapipeline:Tag", "datapipeline:workerGroup"}},
+	{Service: "datapipeline", Name: "ListPipelines", AccessLevel: AccessList},
+	{Service: "datapipeline", Name: "PutPipelineDefinition", AccessLevel: AccessWrite, ConditionKeys: []string{"datapipeline:PipelineCreator", "datapipeline:Tag", "datapipeline:workerGroup"}},
+	{Service: "dynamodb", Name: "BatchGetItem", AccessLevel: AccessRead, ResourceTypes: []string{"table"}, ConditionKeys: []string{"dynamodb:Attributes", "dynamodb:LeadingKeys", "dynamodb:ReturnConsumedCapacity", "dynamodb:Select"}},
+	{Service: "dynamodb", Name: "BatchWriteItem", AccessLevel: AccessWrite, ResourceTypes: []string{"table"}, ConditionKeys: []string{"dynamodb:Attributes", "dynamodb:LeadingKeys", "dynamodb:ReturnConsumedCapacity", "dynamodb:ReturnValues"}},
+	{Service: "dynamodb", Name: "ConditionCheckItem", AccessLevel: AccessRead, ResourceTypes: []string{"table"}, ConditionKeys: []string{"dynamodb:Attributes", "dynamodb:LeadingKeys", "dynamodb:ReturnConsumedCapacity", "dynamodb:Select"}},
+	{Service: "dynamodb", Name: "CreateBackup", AccessLevel: AccessWrite, ResourceTypes: []string{"table"}},
+	{Service: "dynamodb", Name: "CreateTable", AccessLevel: AccessWrite, ResourceTypes: []string{"table"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "dynamodb", Name: "DeleteBackup", AccessLevel: AccessWrite, ResourceTypes: []string{"backup"}},
+	{Service: "dynamodb", Name: "DeleteItem", AccessLevel: AccessWrite, ResourceTypes: []string{"table"}, ConditionKeys: []string{"dynamodb:Attributes", "dynamodb:LeadingKeys", "dynamodb:ReturnConsumedCapacity", "dynamodb:ReturnValues"}},
+	{Service: "dynamodb", Name: "DeleteTable", AccessLevel: AccessWrite, ResourceTypes: []string{"table"}},
+	{Service: "dynamodb", Name: "DescribeBackup", AccessLevel: AccessRead, ResourceTypes: []string{"backup"}},
+	{Service: "dynamodb", Name: "DescribeContinuousBackups", AccessLevel: AccessRead, ResourceTypes: []string{"table"}},
+	{Service: "dynamodb", Name: "DescribeStream", AccessLevel: AccessRead, ResourceTypes: []string{"stream"}},
+	{Service: "dynamodb", Name: "DescribeTable", AccessLevel: AccessRead, ResourceTypes: []string{"table"}},
+	{Service: "dynamodb", Name: "GetItem", AccessLevel: AccessRead, ResourceTypes: []string{"table"}, ConditionKeys: []string{"dynamodb:Attributes", "dynamodb:LeadingKeys", "dynamodb:ReturnConsumedCapacity", "dynamodb:Select"}},
+	{Service: "dynamodb", Name: "GetRecords", AccessLevel: AccessRead, ResourceTypes: []string{"stream"}},
+	{Service: "dynamodb", Name: "GetShardIterator", AccessLevel: AccessRead, ResourceTypes: []string{"stream"}},
+	{Service: "dynamodb", Name: "ListBackups", AccessLevel: AccessList},
+	{Service: "dynamodb", Name: "ListStreams", AccessLevel: AccessRead},
+	{Service: "dynamodb", Name: "ListTables", AccessLevel: AccessList},
+	{Service: "dynamodb", Name: "ListTagsOfResource", AccessLevel: AccessRead, ResourceTypes: []string{"table"}},
+	{Service: "dynamodb", Name: "PutItem", AccessLevel: AccessWrite, ResourceTypes: []string{"table"}, ConditionKeys: []string{"dynamodb:Attributes", "dynamodb:LeadingKeys", "dynamodb:ReturnConsumedCapacity", "dynamodb:ReturnValues"}},
+	{Service: "dynamodb", Name: "Query", AccessLevel: AccessRead, ResourceTypes: []string{"index", "table"}, ConditionKeys: []string{"dynamodb:Attributes", "dynamodb:LeadingKeys", "dynamodb:ReturnConsumedCapacity", "dynamodb:Select"}},
+	{Service: "dynamodb", Name: "RestoreTableFromBackup", AccessLevel: AccessWrite, ResourceTypes: []string{"backup", "table"}},
+	{Service: "dynamodb", Name: "Scan", AccessLevel: AccessRead, ResourceTypes: []string{"index", "table"}, ConditionKeys: []string{"dynamodb:Attributes", "dynamodb:LeadingKeys", "dynamodb:ReturnConsumedCapacity", "dynamodb:Select"}},
+	{Service: "dynamodb", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"table"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "dynamodb", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"table"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "dynamodb", Name: "UpdateItem", AccessLevel: AccessWrite, ResourceTypes: []string{"table"}, ConditionKeys: []string{"dynamodb:Attributes", "dynamodb:LeadingKeys", "dynamodb:ReturnConsumedCapacity", "dynamodb:ReturnValues"}},
+	{Service: "dynamodb", Name: "UpdateTable", AccessLevel: AccessWrite, ResourceTypes: []string{"table"}},
+	{Service: "ec2", Name: "AssignPrivateIpAddresses", AccessLevel: AccessWrite, ResourceTypes: []string{"network-interface"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "ec2:Region"}},
+	{Service: "ec2", Name: "CopyImage", AccessLevel: AccessWrite, ResourceTypes: []string{"image"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:ResourceTag/${TagKey}", "aws:TagKeys", "ec2:Region"}},
+	{Service: "ec2", Name: "CreateNetworkInterface", AccessLevel: AccessWrite, ResourceTypes: []string{"network-interface", "security-group", "subnet"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:ResourceTag/${TagKey}", "aws:TagKeys", "ec2:Region", "ec2:Subnet", "ec2:Vpc"}},
+	{Service: "ec2", Name: "CreateTags", AccessLevel: AccessTagging, ResourceTypes: []string{"image", "instance", "network-interface", "security-group", "subnet", "volume"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "ec2:CreateAction", "ec2:Region"}},
+	{Service: "ec2", Name: "DeleteNetworkInterface", AccessLevel: AccessWrite, ResourceTypes: []string{"network-interface"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "ec2:Region"}},
+	{Service: "ec2", Name: "DeleteTags", AccessLevel: AccessTagging, ResourceTypes: []string{"image", "instance", "network-interface", "security-group", "subnet", "volume"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "aws:TagKeys", "ec2:Region"}},
+	{Service: "ec2", Name: "DescribeImages", AccessLevel: AccessList},
+	{Service: "ec2", Name: "DescribeInstances", AccessLevel: AccessList},
+	{Service: "ec2", Name: "DescribeNetworkInterfaces", AccessLevel: AccessList},
+	{Service: "ec2", Name: "DescribeRegions", AccessLevel: AccessList},
+	{Service: "ec2", Name: "DescribeSecurityGroups", AccessLevel: AccessList},
+	{Service: "ec2", Name: "DescribeSubnets", AccessLevel: AccessList},
+	{Service: "ec2", Name: "DescribeTransitGateways", AccessLevel: AccessList},
+	{Service: "ec2", Name: "DescribeVpcs", AccessLevel: AccessList},
+	{Service: "ec2", Name: "DescribeVpnGateways", AccessLevel: AccessList},
+	{Service: "ec2", Name: "DetachNetworkInterface", AccessLevel: AccessWrite, ResourceTypes: []string{"instance", "network-interface"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "ec2:Region"}},
+	{Service: "ec2", Name: "RunInstances", AccessLevel: AccessWrite, ResourceTypes: []string{"image", "instance", "key-pair", "launch-template", "network-interface", "security-group", "subnet", "volume"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:ResourceTag/${TagKey}", "aws:TagKeys", "ec2:InstanceProfile", "ec2:InstanceType", "ec2:Region", "ec2:Subnet", "ec2:Vpc"}},
+	{Service: "ec2", Name: "StartInstances", AccessLevel: AccessWrite, ResourceTypes: []string{"instance"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "ec2:Region"}},
+	{Service: "ec2", Name: "StopInstances", AccessLevel: AccessWrite, ResourceTypes: []string{"instance"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "ec2:Region"}},
+	{Service: "ec2", Name: "TerminateInstances", AccessLevel: AccessWrite, ResourceTypes: []string{"instance"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "ec2:Region"}},
+	{Service: "ec2", Name: "UnassignPrivateIpAddresses", AccessLevel: AccessWrite, ResourceTypes: []string{"network-interface"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "ec2:Region"}},
+	{Service: "eks", Name: "CreateCluster", AccessLevel: AccessWrite, ResourceTypes: []string{"cluster"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "eks", Name: "DeleteCluster", AccessLevel: AccessWrite, ResourceTypes: []string{"cluster"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "eks", Name: "DescribeCluster", AccessLevel: AccessRead, ResourceTypes: []string{"cluster"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "eks", Name: "ListClusters", AccessLevel: AccessList},
+	{Service: "eks", Name: "ListTagsForResource", AccessLevel: AccessRead, ResourceTypes: []string{"cluster"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "eks", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"cluster"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:ResourceTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "eks", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"cluster"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "eks", Name: "UpdateClusterConfig", AccessLevel: AccessWrite, ResourceTypes: []string{"cluster"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}"}},
+	{Service: "es", Name: "AddTags", AccessLevel: AccessTagging, ResourceTypes: []string{"domain"}},
+	{Service: "es", Name: "CreateElasticsearchDomain", AccessLevel: AccessWrite, ResourceTypes: []string{"domain"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "es", Name: "DeleteElasticsearchDomain", AccessLevel: AccessWrite, ResourceTypes: []string{"domain"}},
+	{Service: "es", Name: "DescribeElasticsearchDomain", AccessLevel: AccessRead, ResourceTypes: []string{"domain"}},
+	{Service: "es", Name: "ESHttpDelete", AccessLevel: AccessWrite, ResourceTypes: []string{"domain"}},
+	{Service: "es", Name: "ESHttpGet", AccessLevel: AccessRead, ResourceTypes: []string{"domain"}},
+	{Service: "es", Name: "ESHttpHead", AccessLevel: AccessRead, ResourceTypes: []string{"domain"}},
+	{Service: "es", Name: "ESHttpPatch", AccessLevel: AccessWrite, ResourceTypes: []string{"domain"}},
+	{Service: "es", Name: "ESHttpPost", AccessLevel: AccessWrite, ResourceTypes: []string{"domain"}},
+	{Service: "es", Name: "ESHttpPut", AccessLevel: AccessWrite, ResourceTypes: []string{"domain"}},
+	{Service: "es", Name: "ListDomainNames", AccessLevel: AccessList},
+	{Service: "es", Name: "ListTags", AccessLevel: AccessRead, ResourceTypes: []string{"domain"}},
+	{Service: "es", Name: "RemoveTags", AccessLevel: AccessTagging, ResourceTypes: []string{"domain"}},
+	{Service: "events", Name: "DeleteRule", AccessLevel: AccessWrite, ResourceTypes: []string{"rule"}, ConditionKeys: []string{"events:ManagedBy", "events:creatorAccount"}},
+	{Service: "events", Name: "DescribeRule", AccessLevel: AccessRead, ResourceTypes: []string{"rule"}, ConditionKeys: []string{"events:creatorAccount"}},
+	{Service: "events", Name: "DisableRule", AccessLevel: AccessWrite, ResourceTypes: []string{"rule"}, ConditionKeys: []string{"events:ManagedBy", "events:creatorAccount"}},
+	{Service: "events", Name: "EnableRule", AccessLevel: AccessWrite, ResourceTypes: []string{"rule"}, ConditionKeys: []string{"events:ManagedBy", "events:creatorAccount"}},
+	{Service: "events", Name: "ListRules", AccessLevel: AccessList},
+	{Service: "events", Name: "ListTagsForResource", AccessLevel: AccessList, ResourceTypes: []string{"rule"}},
+	{Service: "events", Name: "PutEvents", AccessLevel: AccessWrite, ResourceTypes: []string{"event-bus"}, ConditionKeys: []string{"events:detail-type", "events:source"}},
+	{Service: "events", Name: "PutRule", AccessLevel: AccessWrite, ResourceTypes: []string{"rule"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "events:ManagedBy", "events:creatorAccount", "events:detail-type", "events:source"}},
+	{Service: "events", Name: "PutTargets", AccessLevel: AccessWrite, ResourceTypes: []string{"rule"}, ConditionKeys: []string{"events:ManagedBy", "events:TargetArn", "events:creatorAccount"}},
+	{Service: "events", Name: "RemoveTargets", AccessLevel: AccessWrite, ResourceTypes: []string{"rule"}, ConditionKeys: []string{"events:ManagedBy", "events:creatorAccount"}},
+	{Service: "events", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"rule"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "events", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"rule"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "execute-api", Name: "InvalidateCache", AccessLevel: AccessWrite, ResourceTypes: []string{"execute-api-general"}},
+	{Service: "execute-api", Name: "Invoke", AccessLevel: AccessWrite, ResourceTypes: []string{"execute-api-general"}},
+	{Service: "execute-api", Name: "ManageConnections", AccessLevel: AccessWrite, ResourceTypes: []string{"execute-api-general"}},
+	{Service: "firehose", Name: "CreateDeliveryStream", AccessLevel: AccessWrite, ResourceTypes: []string{"deliverystream"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "firehose", Name: "DeleteDeliveryStream", AccessLevel: AccessWrite, ResourceTypes: []string{"deliverystream"}},
+	{Service: "firehose", Name: "DescribeDeliveryStream", AccessLevel: AccessRead, ResourceTypes: []string{"deliverystream"}},
+	{Service: "firehose", Name: "ListDeliveryStreams", AccessLevel: AccessList},
+	{Service: "firehose", Name: "ListTagsForDeliveryStream", AccessLevel: AccessList, ResourceTypes: []string{"deliverystream"}},
+	{Service: "firehose", Name: "PutRecord", AccessLevel: AccessWrite, ResourceTypes: []string{"deliverystream"}},
+	{Service: "firehose", Name: "PutRecordBatch", AccessLevel: AccessWrite, ResourceTypes: []string{"deliverystream"}},
+	{Service: "firehose", Name: "TagDeliveryStream", AccessLevel: AccessTagging, ResourceTypes: []string{"deliverystream"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "firehose", Name: "UntagDeliveryStream", AccessLevel: AccessTagging, ResourceTypes: []string{"deliverystream"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "firehose", Name: "UpdateDestination", AccessLevel: AccessWrite, ResourceTypes: []string{"deliverystream"}},
+	{Service: "glue", Name: "CreateDevEndpoint", AccessLevel: AccessWrite, ResourceTypes: []string{"devendpoint"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "glue", Name: "CreateJob", AccessLevel: AccessWrite, ResourceTypes: []string{"job"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "glue", Name: "CreateTable", AccessLevel: AccessWrite, ResourceTypes: []string{"catalog", "database", "table"}},
+	{Service: "glue", Name: "GetDatabase", AccessLevel: AccessRead, ResourceTypes: []string{"catalog", "database"}},
+	{Service: "glue", Name: "GetDatabases", AccessLevel: AccessRead, ResourceTypes: []string{"catalog", "database"}},
+	{Service: "glue", Name: "GetDevEndpoint", AccessLevel: AccessRead, ResourceTypes: []string{"devendpoint"}},
+	{Service: "glue", Name: "GetJobRun", AccessLevel: AccessRead, ResourceTypes: []string{"job"}},
+	{Service: "glue", Name: "GetTable", AccessLevel: AccessRead, ResourceTypes: []string{"catalog", "database", "table"}},
+	{Service: "glue", Name: "GetTables", AccessLevel: AccessRead, ResourceTypes: []string{"catalog", "database", "table"}},
+	{Service: "glue", Name: "StartJobRun", AccessLevel: AccessWrite, ResourceTypes: []string{"job"}},
+	{Service: "glue", Name: "UpdateDevEndpoint", AccessLevel: AccessWrite, ResourceTypes: []string{"devendpoint"}},
+	{Service: "iam", Name: "AddRoleToInstanceProfile", AccessLevel: AccessWrite, ResourceTypes: []string{"instance-profile"}},
+	{Service: "iam", Name: "AddUserToGroup", AccessLevel: AccessWrite, ResourceTypes: []string{"group"}},
+	{Service: "iam", Name: "AttachGroupPolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"group"}, ConditionKeys: []string{"iam:PolicyARN"}},
+	{Service: "iam", Name: "AttachRolePolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"role"}, ConditionKeys: []string{"iam:PermissionsBoundary", "iam:PolicyARN"}},
+	{Service: "iam", Name: "AttachUserPolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"user"}, ConditionKeys: []string{"iam:PermissionsBoundary", "iam:PolicyARN"}},
+	{Service: "iam", Name: "CreateAccessKey", AccessLevel: AccessWrite, ResourceTypes: []string{"user"}},
+	{Service: "iam", Name: "CreateInstanceProfile", AccessLevel: AccessWrite, ResourceTypes: []string{"instance-profile"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "iam", Name: "CreateLoginProfile", AccessLevel: AccessWrite, ResourceTypes: []string{"user"}},
+	{Service: "iam", Name: "CreatePolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"policy"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "iam", Name: "CreatePolicyVersion", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"policy"}},
+	{Service: "iam", Name: "CreateRole", AccessLevel: AccessWrite, ResourceTypes: []string{"role"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "iam:PermissionsBoundary"}},
+	{Service: "iam", Name: "CreateServiceLinkedRole", AccessLevel: AccessWrite, ResourceTypes: []string{"role"}, ConditionKeys: []string{"iam:AWSServiceName"}},
+	{Service: "iam", Name: "CreateUser", AccessLevel: AccessWrite, ResourceTypes: []string{"user"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "iam:PermissionsBoundary"}},
+	{Service: "iam", Name: "DeleteAccessKey", AccessLevel: AccessWrite, ResourceTypes: []string{"user"}},
+	{Service: "iam", Name: "DeletePolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"policy"}},
+	{Service: "iam", Name: "DeletePolicyVersion", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"policy"}},
+	{Service: "iam", Name: "DeleteRole", AccessLevel: AccessWrite, ResourceTypes: []string{"role"}},
+	{Service: "iam", Name: "DeleteRolePolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"role"}, ConditionKeys: []string{"iam:PermissionsBoundary"}},
+	{Service: "iam", Name: "DeleteUser", AccessLevel: AccessWrite, ResourceTypes: []string{"user"}},
+	{Service: "iam", Name: "DetachGroupPolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"group"}, ConditionKeys: []string{"iam:PolicyARN"}},
+	{Service: "iam", Name: "DetachRolePolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"role"}, ConditionKeys: []string{"iam:PermissionsBoundary", "iam:PolicyARN"}},
+	{Service: "iam", Name: "DetachUserPolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"user"}, ConditionKeys: []string{"iam:PermissionsBoundary", "iam:PolicyARN"}},
+	{Service: "iam", Name: "GenerateCredentialReport", AccessLevel: AccessRead},
+	{Service: "iam", Name: "GetAccountAuthorizationDetails", AccessLevel: AccessRead},
+	{Service: "iam", Name: "GetPolicy", AccessLevel: AccessRead, ResourceTypes: []string{"policy"}},
+	{Service: "iam", Name: "GetPolicyVersion", AccessLevel: AccessRead, ResourceTypes: []string{"policy"}},
+	{Service: "iam", Name: "GetRole", AccessLevel: AccessRead, ResourceTypes: []string{"role"}},
+	{Service: "iam", Name: "GetRolePolicy", AccessLevel: AccessRead, ResourceTypes: []string{"role"}},
+	{Service: "iam", Name: "GetUser", AccessLevel: AccessRead, ResourceTypes: []string{"user"}},
+	{Service: "iam", Name: "ListAccessKeys", AccessLevel: AccessList, ResourceTypes: []string{"user"}},
+	{Service: "iam", Name: "ListAttachedRolePolicies", AccessLevel: AccessList, ResourceTypes: []string{"role"}},
+	{Service: "iam", Name: "ListPolicies", AccessLevel: AccessList},
+	{Service: "iam", Name: "ListRolePolicies", AccessLevel: AccessList, ResourceTypes: []string{"role"}},
+	{Service: "iam", Name: "ListRoles", AccessLevel: AccessList},
+	{Service: "iam", Name: "ListUsers", AccessLevel: AccessList},
+	{Service: "iam", Name: "PassRole", AccessLevel: AccessWrite, ResourceTypes: []string{"role"}, ConditionKeys: []string{"iam:AssociatedResourceArn", "iam:PassedToService"}},
+	{Service: "iam", Name: "PutGroupPolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"group"}},
+	{Service: "iam", Name: "PutRolePermissionsBoundary", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"role"}, ConditionKeys: []string{"iam:PermissionsBoundary"}},
+	{Service: "iam", Name: "PutRolePolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"role"}, ConditionKeys: []string{"iam:PermissionsBoundary"}},
+	{Service: "iam", Name: "PutUserPolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"user"}, ConditionKeys: []string{"iam:PermissionsBoundary"}},
+	{Service: "iam", Name: "SetDefaultPolicyVersion", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"policy"}},
+	{Service: "iam", Name: "TagRole", AccessLevel: AccessTagging, ResourceTypes: []string{"role"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "iam", Name: "TagUser", AccessLevel: AccessTagging, ResourceTypes: []string{"user"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "iam", Name: "UntagRole", AccessLevel: AccessTagging, ResourceTypes: []string{"role"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "iam", Name: "UntagUser", AccessLevel: AccessTagging, ResourceTypes: []string{"user"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "iam", Name: "UpdateAccessKey", AccessLevel: AccessWrite, ResourceTypes: []string{"user"}},
+	{Service: "iam", Name: "UpdateAssumeRolePolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"role"}},
+	{Service: "iam", Name: "UpdateLoginProfile", AccessLevel: AccessWrite, ResourceTypes: []string{"user"}},
+	{Service: "iam", Name: "UpdateRole", AccessLevel: AccessWrite, ResourceTypes: []string{"role"}},
+	{Service: "kinesis", Name: "AddTagsToStream", AccessLevel: AccessTagging, ResourceTypes: []string{"stream"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "kinesis", Name: "CreateStream", AccessLevel: AccessWrite, ResourceTypes: []string{"stream"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "kinesis", Name: "DecreaseStreamRetentionPeriod", AccessLevel: AccessWrite, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "DeleteStream", AccessLevel: AccessWrite, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "DescribeLimits", AccessLevel: AccessRead},
+	{Service: "kinesis", Name: "DescribeStream", AccessLevel: AccessRead, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "DescribeStreamConsumer", AccessLevel: AccessRead, ResourceTypes: []string{"consumer"}},
+	{Service: "kinesis", Name: "DescribeStreamSummary", AccessLevel: AccessRead, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "GetRecords", AccessLevel: AccessRead, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "GetShardIterator", AccessLevel: AccessRead, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "IncreaseStreamRetentionPeriod", AccessLevel: AccessWrite, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "ListShards", AccessLevel: AccessList, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "ListStreams", AccessLevel: AccessList},
+	{Service: "kinesis", Name: "ListTagsForStream", AccessLevel: AccessRead, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "MergeShards", AccessLevel: AccessWrite, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "PutRecord", AccessLevel: AccessWrite, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "PutRecords", AccessLevel: AccessWrite, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "RegisterStreamConsumer", AccessLevel: AccessWrite, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "RemoveTagsFromStream", AccessLevel: AccessTagging, ResourceTypes: []string{"stream"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "kinesis", Name: "SplitShard", AccessLevel: AccessWrite, ResourceTypes: []string{"stream"}},
+	{Service: "kinesis", Name: "SubscribeToShard", AccessLevel: AccessRead, ResourceTypes: []string{"consumer"}},
+	{Service: "kms", Name: "CreateAlias", AccessLevel: AccessWrite, ResourceTypes: []string{"alias", "key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:ViaService"}},
+	{Service: "kms", Name: "CreateGrant", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:GrantIsForAWSResource", "kms:GrantOperations", "kms:GranteePrincipal", "kms:ViaService"}},
+	{Service: "kms", Name: "CreateKey", AccessLevel: AccessWrite, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "kms:KeySpec", "kms:KeyUsage"}},
+	{Service: "kms", Name: "Decrypt", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:EncryptionAlgorithm", "kms:EncryptionContext:${EncryptionContextKey}", "kms:EncryptionContextKeys", "kms:ViaService"}},
+	{Service: "kms", Name: "DescribeKey", AccessLevel: AccessRead, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:ViaService"}},
+	{Service: "kms", Name: "DisableKey", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:ViaService"}},
+	{Service: "kms", Name: "EnableKey", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:ViaService"}},
+	{Service: "kms", Name: "Encrypt", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:EncryptionAlgorithm", "kms:EncryptionContext:${EncryptionContextKey}", "kms:EncryptionContextKeys", "kms:ViaService"}},
+	{Service: "kms", Name: "GenerateDataKey", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:EncryptionAlgorithm", "kms:EncryptionContext:${EncryptionContextKey}", "kms:EncryptionContextKeys", "kms:ViaService"}},
+	{Service: "kms", Name: "GenerateDataKeyPair", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:EncryptionAlgorithm", "kms:EncryptionContext:${EncryptionContextKey}", "kms:EncryptionContextKeys", "kms:ViaService"}},
+	{Service: "kms", Name: "GenerateDataKeyWithoutPlaintext", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:EncryptionAlgorithm", "kms:EncryptionContext:${EncryptionContextKey}", "kms:EncryptionContextKeys", "kms:ViaService"}},
+	{Service: "kms", Name: "GetKeyPolicy", AccessLevel: AccessRead, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:ViaService"}},
+	{Service: "kms", Name: "ListAliases", AccessLevel: AccessList},
+	{Service: "kms", Name: "ListKeys", AccessLevel: AccessList},
+	{Service: "kms", Name: "PutKeyPolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:BypassPolicyLockoutSafetyCheck", "kms:CallerAccount", "kms:ViaService"}},
+	{Service: "kms", Name: "ReEncryptFrom", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:EncryptionAlgorithm", "kms:EncryptionContext:${EncryptionContextKey}", "kms:EncryptionContextKeys", "kms:ViaService"}},
+	{Service: "kms", Name: "ReEncryptTo", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:EncryptionAlgorithm", "kms:EncryptionContext:${EncryptionContextKey}", "kms:EncryptionContextKeys", "kms:ViaService"}},
+	{Service: "kms", Name: "ScheduleKeyDeletion", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:ViaService"}},
+	{Service: "kms", Name: "Sign", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:SigningAlgorithm", "kms:ViaService"}},
+	{Service: "kms", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"key"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "kms", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"key"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "kms", Name: "Verify", AccessLevel: AccessWrite, ResourceTypes: []string{"key"}, ConditionKeys: []string{"kms:CallerAccount", "kms:SigningAlgorithm", "kms:ViaService"}},
+	{Service: "lambda", Name: "AddPermission", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"function"}, ConditionKeys: []string{"lambda:FunctionUrlAuthType", "lambda:Principal"}},
+	{Service: "lambda", Name: "CreateAlias", AccessLevel: AccessWrite, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "CreateEventSourceMapping", AccessLevel: AccessWrite, ConditionKeys: []string{"lambda:FunctionArn"}},
+	{Service: "lambda", Name: "CreateFunction", AccessLevel: AccessWrite, ResourceTypes: []string{"function"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "lambda:CodeSigningConfigArn", "lambda:Layer", "lambda:SecurityGroupIds", "lambda:SubnetIds", "lambda:VpcIds"}},
+	{Service: "lambda", Name: "DeleteEventSourceMapping", AccessLevel: AccessWrite, ResourceTypes: []string{"eventSourceMapping"}, ConditionKeys: []string{"lambda:FunctionArn"}},
+	{Service: "lambda", Name: "DeleteFunction", AccessLevel: AccessWrite, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "GetFunction", AccessLevel: AccessRead, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "GetFunctionConfiguration", AccessLevel: AccessRead, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "GetLayerVersion", AccessLevel: AccessRead, ResourceTypes: []string{"layerVersion"}},
+	{Service: "lambda", Name: "GetPolicy", AccessLevel: AccessRead, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "InvokeAsync", AccessLevel: AccessWrite, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "InvokeFunction", AccessLevel: AccessWrite, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "ListEventSourceMappings", AccessLevel: AccessList},
+	{Service: "lambda", Name: "ListFunctions", AccessLevel: AccessList},
+	{Service: "lambda", Name: "ListTags", AccessLevel: AccessRead, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "PublishLayerVersion", AccessLevel: AccessWrite, ResourceTypes: []string{"layer"}},
+	{Service: "lambda", Name: "PublishVersion", AccessLevel: AccessWrite, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "PutFunctionConcurrency", AccessLevel: AccessWrite, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "RemovePermission", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"function"}, ConditionKeys: []string{"lambda:FunctionUrlAuthType", "lambda:Principal"}},
+	{Service: "lambda", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"function"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "lambda", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"function"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "lambda", Name: "UpdateAlias", AccessLevel: AccessWrite, ResourceTypes: []string{"function"}},
+	{Service: "lambda", Name: "UpdateEventSourceMapping", AccessLevel: AccessWrite, ResourceTypes: []string{"eventSourceMapping"}, ConditionKeys: []string{"lambda:FunctionArn"}},
+	{Service: "lambda", Name: "UpdateFunctionCode", AccessLevel: AccessWrite, ResourceTypes: []string{"function"}, ConditionKeys: []string{"lambda:CodeSigningConfigArn"}},
+	{Service: "lambda", Name: "UpdateFunctionConfiguration", AccessLevel: AccessWrite, ResourceTypes: []string{"function"}, ConditionKeys: []string{"lambda:CodeSigningConfigArn", "lambda:Layer", "lambda:SecurityGroupIds", "lambda:SubnetIds", "lambda:VpcIds"}},
+	{Service: "logs", Name: "CreateLogDelivery", AccessLevel: AccessWrite},
+	{Service: "logs", Name: "CreateLogGroup", AccessLevel: AccessWrite, ResourceTypes: []string{"log-group"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "logs", Name: "CreateLogStream", AccessLevel: AccessWrite, ResourceTypes: []string{"log-group"}},
+	{Service: "logs", Name: "DeleteLogGroup", AccessLevel: AccessWrite, ResourceTypes: []string{"log-group"}},
+	{Service: "logs", Name: "DescribeLogGroups", AccessLevel: AccessList},
+	{Service: "logs", Name: "DescribeLogStreams", AccessLevel: AccessList, ResourceTypes: []string{"log-group"}},
+	{Service: "logs", Name: "FilterLogEvents", AccessLevel: AccessRead, ResourceTypes: []string{"log-group"}},
+	{Service: "logs", Name: "GetLogEvents", AccessLevel: AccessRead, ResourceTypes: []string{"log-stream"}},
+	{Service: "logs", Name: "GetQueryResults", AccessLevel: AccessRead},
+	{Service: "logs", Name: "PutLogEvents", AccessLevel: AccessWrite, ResourceTypes: []string{"log-stream"}},
+	{Service: "logs", Name: "PutResourcePolicy", AccessLevel: AccessWrite},
+	{Service: "logs", Name: "PutRetentionPolicy", AccessLevel: AccessWrite, ResourceTypes: []string{"log-group"}},
+	{Service: "logs", Name: "PutSubscriptionFilter", AccessLevel: AccessWrite, ResourceTypes: []string{"log-group"}},
+	{Service: "logs", Name: "StartQuery", AccessLevel: AccessRead, ResourceTypes: []string{"log-group"}},
+	{Service: "logs", Name: "TagLogGroup", AccessLevel: AccessTagging, ResourceTypes: []string{"log-group"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "logs", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"log-group"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "logs", Name: "UntagLogGroup", AccessLevel: AccessTagging, ResourceTypes: []string{"log-group"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "logs", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"log-group"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "mobileanalytics", Name: "GetReports", AccessLevel: AccessRead},
+	{Service: "mobileanalytics", Name: "PutEvents", AccessLevel: AccessWrite},
+	{Service: "mobiletargeting", Name: "CreateApp", AccessLevel: AccessWrite, ResourceTypes: []string{"apps"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "mobiletargeting", Name: "GetApp", AccessLevel: AccessRead, ResourceTypes: []string{"apps"}},
+	{Service: "mobiletargeting", Name: "GetApps", AccessLevel: AccessList, ResourceTypes: []string{"apps"}},
+	{Service: "mobiletargeting", Name: "GetEndpoint", AccessLevel: AccessRead, ResourceTypes: []string{"apps"}},
+	{Service: "mobiletargeting", Name: "SendMessages", AccessLevel: AccessWrite, ResourceTypes: []string{"apps"}},
+	{Service: "mobiletargeting", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"apps"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "mobiletargeting", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"apps"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "mobiletargeting", Name: "UpdateEndpoint", AccessLevel: AccessWrite, ResourceTypes: []string{"apps"}},
+	{Service: "mobiletargeting", Name: "UpdateEndpointsBatch", AccessLevel: AccessWrite, ResourceTypes: []string{"apps"}},
+	{Service: "organizations", Name: "CreateAccount", AccessLevel: AccessWrite, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "organizations", Name: "DescribeAccount", AccessLevel: AccessRead, ResourceTypes: []string{"account"}},
+	{Service: "organizations", Name: "DescribeOrganization", AccessLevel: AccessRead},
+	{Service: "organizations", Name: "LeaveOrganization", AccessLevel: AccessWrite},
+	{Service: "organizations", Name: "ListAccounts", AccessLevel: AccessList},
+	{Service: "organizations", Name: "ListOrganizationalUnitsForParent", AccessLevel: AccessList, ResourceTypes: []string{"ou", "root"}},
+	{Service: "organizations", Name: "ListRoots", AccessLevel: AccessList},
+	{Service: "organizations", Name: "ListTagsForResource", AccessLevel: AccessRead, ResourceTypes: []string{"account", "ou", "policy", "root"}},
+	{Service: "organizations", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"account", "ou", "policy", "root"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "organizations", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"account", "ou", "policy", "root"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "polly", Name: "DeleteLexicon", AccessLevel: AccessWrite, ResourceTypes: []string{"lexicon"}},
+	{Service: "polly", Name: "DescribeVoices", AccessLevel: AccessList},
+	{Service: "polly", Name: "GetLexicon", AccessLevel: AccessRead, ResourceTypes: []string{"lexicon"}},
+	{Service: "polly", Name: "GetSpeechSynthesisTask", AccessLevel: AccessRead},
+	{Service: "polly", Name: "ListLexicons", AccessLevel: AccessList},
+	{Service: "polly", Name: "ListSpeechSynthesisTasks", AccessLevel: AccessList},
+	{Service: "polly", Name: "PutLexicon", AccessLevel: AccessWrite, ResourceTypes: []string{"lexicon"}},
+	{Service: "polly", Name: "StartSpeechSynthesisTask", AccessLevel: AccessWrite, ResourceTypes: []string{"lexicon"}},
+	{Service: "polly", Name: "SynthesizeSpeech", AccessLevel: AccessRead, ResourceTypes: []string{"lexicon"}},
+	{Service: "rekognition", Name: "CompareFaces", AccessLevel: AccessRead},
+	{Service: "rekognition", Name: "CreateCollection", AccessLevel: AccessWrite, ResourceTypes: []string{"collection"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "rekognition", Name: "DeleteCollection", AccessLevel: AccessWrite, ResourceTypes: []string{"collection"}},
+	{Service: "rekognition", Name: "DeleteFaces", AccessLevel: AccessWrite, ResourceTypes: []string{"collection"}},
+	{Service: "rekognition", Name: "DescribeCollection", AccessLevel: AccessRead, ResourceTypes: []string{"collection"}},
+	{Service: "rekognition", Name: "DetectFaces", AccessLevel: AccessRead},
+	{Service: "rekognition", Name: "DetectLabels", AccessLevel: AccessRead},
+	{Service: "rekognition", Name: "DetectModerationLabels", AccessLevel: AccessRead},
+	{Service: "rekognition", Name: "DetectText", AccessLevel: AccessRead},
+	{Service: "rekognition", Name: "IndexFaces", AccessLevel: AccessWrite, ResourceTypes: []string{"collection"}},
+	{Service: "rekognition", Name: "ListCollections", AccessLevel: AccessList, ResourceTypes: []string{"collection"}},
+	{Service: "rekognition", Name: "ListFaces", AccessLevel: AccessRead, ResourceTypes: []string{"collection"}},
+	{Service: "rekognition", Name: "ListTagsForResource", AccessLevel: AccessRead, ResourceTypes: []string{"collection"}},
+	{Service: "rekognition", Name: "RecognizeCelebrities", AccessLevel: AccessRead},
+	{Service: "rekognition", Name: "SearchFaces", AccessLevel: AccessRead, ResourceTypes: []string{"collection"}},
+	{Service: "rekognition", Name: "SearchFacesByImage", AccessLevel: AccessRead, ResourceTypes: []string{"collection"}},
+	{Service: "rekognition", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"collection"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "rekognition", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"collection"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "s3", Name: "AbortMultipartUpload", AccessLevel: AccessWrite, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "CreateBucket", AccessLevel: AccessWrite, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-acl", "s3:x-amz-content-sha256", "s3:x-amz-object-ownership"}},
+	{Service: "s3", Name: "DeleteBucket", AccessLevel: AccessWrite, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "DeleteBucketPolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "DeleteObject", AccessLevel: AccessWrite, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "DeleteObjectTagging", AccessLevel: AccessTagging, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ExistingObjectTag/${TagKey}", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "DeleteObjectVersion", AccessLevel: AccessWrite, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:VersionId", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "DeleteObjectVersionTagging", AccessLevel: AccessTagging, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ExistingObjectTag/${TagKey}", "s3:ResourceAccount", "s3:TlsVersion", "s3:VersionId", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetAccountPublicAccessBlock", AccessLevel: AccessRead, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetBucketAcl", AccessLevel: AccessRead, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetBucketCORS", AccessLevel: AccessRead, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetBucketLocation", AccessLevel: AccessRead, ResourceTypes: []string{"bucket"}},
+	{Service: "s3", Name: "GetBucketNotification", AccessLevel: AccessRead, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetBucketPolicy", AccessLevel: AccessRead, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetBucketPublicAccessBlock", AccessLevel: AccessRead, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetBucketTagging", AccessLevel: AccessRead, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetBucketVersioning", AccessLevel: AccessRead, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetEncryptionConfiguration", AccessLevel: AccessRead, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetLifecycleConfiguration", AccessLevel: AccessRead, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetObject", AccessLevel: AccessRead, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ExistingObjectTag/${TagKey}", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetObjectAcl", AccessLevel: AccessRead, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ExistingObjectTag/${TagKey}", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetObjectTagging", AccessLevel: AccessRead, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ExistingObjectTag/${TagKey}", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetObjectVersion", AccessLevel: AccessRead, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ExistingObjectTag/${TagKey}", "s3:ResourceAccount", "s3:TlsVersion", "s3:VersionId", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "GetObjectVersionTagging", AccessLevel: AccessRead, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ExistingObjectTag/${TagKey}", "s3:ResourceAccount", "s3:TlsVersion", "s3:VersionId", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "ListAllMyBuckets", AccessLevel: AccessList, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "ListBucket", AccessLevel: AccessList, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:delimiter", "s3:max-keys", "s3:prefix", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "ListBucketMultipartUploads", AccessLevel: AccessList, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "ListBucketVersions", AccessLevel: AccessList, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:delimiter", "s3:max-keys", "s3:prefix", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "ListMultipartUploadParts", AccessLevel: AccessList, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutAccountPublicAccessBlock", AccessLevel: AccessPermissionsManagement, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutBucketAcl", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-acl", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutBucketCORS", AccessLevel: AccessWrite, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutBucketNotification", AccessLevel: AccessWrite, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutBucketPolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutBucketPublicAccessBlock", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutBucketTagging", AccessLevel: AccessTagging, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutBucketVersioning", AccessLevel: AccessWrite, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutEncryptionConfiguration", AccessLevel: AccessWrite, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutLifecycleConfiguration", AccessLevel: AccessWrite, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutObject", AccessLevel: AccessWrite, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:RequestObjectTag/${TagKey}", "s3:RequestObjectTagKeys", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-acl", "s3:x-amz-content-sha256", "s3:x-amz-server-side-encryption", "s3:x-amz-server-side-encryption-aws-kms-key-id", "s3:x-amz-storage-class"}},
+	{Service: "s3", Name: "PutObjectAcl", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ExistingObjectTag/${TagKey}", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-acl", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutObjectTagging", AccessLevel: AccessTagging, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ExistingObjectTag/${TagKey}", "s3:RequestObjectTag/${TagKey}", "s3:RequestObjectTagKeys", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutObjectVersionTagging", AccessLevel: AccessTagging, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ExistingObjectTag/${TagKey}", "s3:RequestObjectTag/${TagKey}", "s3:RequestObjectTagKeys", "s3:ResourceAccount", "s3:TlsVersion", "s3:VersionId", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "PutReplicationConfiguration", AccessLevel: AccessWrite, ResourceTypes: []string{"bucket"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256"}},
+	{Service: "s3", Name: "ReplicateObject", AccessLevel: AccessWrite, ResourceTypes: []string{"object"}, ConditionKeys: []string{"s3:AccessPointNetworkOrigin", "s3:DataAccessPointAccount", "s3:DataAccessPointArn", "s3:ResourceAccount", "s3:TlsVersion", "s3:authType", "s3:signatureAge", "s3:signatureversion", "s3:x-amz-content-sha256", "s3:x-amz-server-side-encryption"}},
+	{Service: "secretsmanager", Name: "CancelRotateSecret", AccessLevel: AccessWrite, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "CreateSecret", AccessLevel: AccessWrite, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "secretsmanager:Description", "secretsmanager:KmsKeyId", "secretsmanager:Name"}},
+	{Service: "secretsmanager", Name: "DeleteResourcePolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "DeleteSecret", AccessLevel: AccessWrite, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:ForceDeleteWithoutRecovery", "secretsmanager:RecoveryWindowInDays", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "DescribeSecret", AccessLevel: AccessRead, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "GetRandomPassword", AccessLevel: AccessRead},
+	{Service: "secretsmanager", Name: "GetResourcePolicy", AccessLevel: AccessRead, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "GetSecretValue", AccessLevel: AccessRead, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:SecretId", "secretsmanager:VersionId", "secretsmanager:VersionStage"}},
+	{Service: "secretsmanager", Name: "ListSecretVersionIds", AccessLevel: AccessRead, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "ListSecrets", AccessLevel: AccessList},
+	{Service: "secretsmanager", Name: "PutResourcePolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:BlockPublicPolicy", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "PutSecretValue", AccessLevel: AccessWrite, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "RestoreSecret", AccessLevel: AccessWrite, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "RotateSecret", AccessLevel: AccessWrite, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:RotationLambdaARN", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:ResourceTag/${TagKey}", "aws:TagKeys", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "aws:TagKeys", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "UpdateSecret", AccessLevel: AccessWrite, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:Description", "secretsmanager:KmsKeyId", "secretsmanager:SecretId"}},
+	{Service: "secretsmanager", Name: "UpdateSecretVersionStage", AccessLevel: AccessWrite, ResourceTypes: []string{"Secret"}, ConditionKeys: []string{"aws:ResourceTag/${TagKey}", "secretsmanager:SecretId", "secretsmanager:VersionStage"}},
+	{Service: "serverlessrepo", Name: "CreateApplication", AccessLevel: AccessWrite},
+	{Service: "serverlessrepo", Name: "CreateApplicationVersion", AccessLevel: AccessWrite, ResourceTypes: []string{"applications"}},
+	{Service: "serverlessrepo", Name: "CreateCloudFormationChangeSet", AccessLevel: AccessWrite, ResourceTypes: []string{"applications"}},
+	{Service: "serverlessrepo", Name: "CreateCloudFormationTemplate", AccessLevel: AccessWrite, ResourceTypes: []string{"applications"}},
+	{Service: "serverlessrepo", Name: "DeleteApplication", AccessLevel: AccessWrite, ResourceTypes: []string{"applications"}},
+	{Service: "serverlessrepo", Name: "GetApplication", AccessLevel: AccessRead, ResourceTypes: []string{"applications"}, ConditionKeys: []string{"serverlessrepo:applicationType"}},
+	{Service: "serverlessrepo", Name: "GetApplicationPolicy", AccessLevel: AccessRead, ResourceTypes: []string{"applications"}},
+	{Service: "serverlessrepo", Name: "ListApplicationDependencies", AccessLevel: AccessList, ResourceTypes: []string{"applications"}},
+	{Service: "serverlessrepo", Name: "ListApplicationVersions", AccessLevel: AccessList, ResourceTypes: []string{"applications"}},
+	{Service: "serverlessrepo", Name: "ListApplications", AccessLevel: AccessList, ConditionKeys: []string{"serverlessrepo:applicationType"}},
+	{Service: "serverlessrepo", Name: "PutApplicationPolicy", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"applications"}},
+	{Service: "serverlessrepo", Name: "SearchApplications", AccessLevel: AccessRead, ConditionKeys: []string{"serverlessrepo:applicationType"}},
+	{Service: "serverlessrepo", Name: "UpdateApplication", AccessLevel: AccessWrite, ResourceTypes: []string{"applications"}},
+	{Service: "ses", Name: "CreateTemplate", AccessLevel: AccessWrite},
+	{Service: "ses", Name: "DeleteIdentity", AccessLevel: AccessWrite},
+	{Service: "ses", Name: "DeleteTemplate", AccessLevel: AccessWrite},
+	{Service: "ses", Name: "GetIdentityVerificationAttributes", AccessLevel: AccessRead},
+	{Service: "ses", Name: "GetTemplate", AccessLevel: AccessRead},
+	{Service: "ses", Name: "ListIdentities", AccessLevel: AccessList},
+	{Service: "ses", Name: "ListTemplates", AccessLevel: AccessList},
+	{Service: "ses", Name: "SendBounce", AccessLevel: AccessWrite, ResourceTypes: []string{"identity"}},
+	{Service: "ses", Name: "SendBulkTemplatedEmail", AccessLevel: AccessWrite, ResourceTypes: []string{"configuration-set", "identity"}, ConditionKeys: []string{"ses:FeedbackAddress", "ses:FromAddress", "ses:FromDisplayName", "ses:Recipients"}},
+	{Service: "ses", Name: "SendEmail", AccessLevel: AccessWrite, ResourceTypes: []string{"configuration-set", "identity"}, ConditionKeys: []string{"ses:FeedbackAddress", "ses:FromAddress", "ses:FromDisplayName", "ses:Recipients"}},
+	{Service: "ses", Name: "SendRawEmail", AccessLevel: AccessWrite, ResourceTypes: []string{"configuration-set", "identity"}, ConditionKeys: []string{"ses:FeedbackAddress", "ses:FromAddress", "ses:FromDisplayName", "ses:Recipients"}},
+	{Service: "ses", Name: "SendTemplatedEmail", AccessLevel: AccessWrite, ResourceTypes: []string{"configuration-set", "identity"}, ConditionKeys: []string{"ses:FeedbackAddress", "ses:FromAddress", "ses:FromDisplayName", "ses:Recipients"}},
+	{Service: "ses", Name: "TestRenderTemplate", AccessLevel: AccessWrite},
+	{Service: "ses", Name: "UpdateTemplate", AccessLevel: AccessWrite},
+	{Service: "ses", Name: "VerifyDomainIdentity", AccessLevel: AccessWrite},
+	{Service: "ses", Name: "VerifyEmailIdentity", AccessLevel: AccessWrite},
+	{Service: "sns", Name: "AddPermission", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"topic"}},
+	{Service: "sns", Name: "ConfirmSubscription", AccessLevel: AccessWrite, ResourceTypes: []string{"topic"}},
+	{Service: "sns", Name: "CreateTopic", AccessLevel: AccessWrite, ResourceTypes: []string{"topic"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "sns", Name: "DeleteTopic", AccessLevel: AccessWrite, ResourceTypes: []string{"topic"}},
+	{Service: "sns", Name: "GetTopicAttributes", AccessLevel: AccessRead, ResourceTypes: []string{"topic"}},
+	{Service: "sns", Name: "ListSubscriptions", AccessLevel: AccessList},
+	{Service: "sns", Name: "ListSubscriptionsByTopic", AccessLevel: AccessList, ResourceTypes: []string{"topic"}},
+	{Service: "sns", Name: "ListTagsForResource", AccessLevel: AccessRead, ResourceTypes: []string{"topic"}},
+	{Service: "sns", Name: "ListTopics", AccessLevel: AccessList},
+	{Service: "sns", Name: "Publish", AccessLevel: AccessWrite, ResourceTypes: []string{"topic"}},
+	{Service: "sns", Name: "RemovePermission", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"topic"}},
+	{Service: "sns", Name: "SetTopicAttributes", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"topic"}},
+	{Service: "sns", Name: "Subscribe", AccessLevel: AccessWrite, ResourceTypes: []string{"topic"}, ConditionKeys: []string{"sns:Endpoint", "sns:Protocol"}},
+	{Service: "sns", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"topic"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "sns", Name: "Unsubscribe", AccessLevel: AccessWrite},
+	{Service: "sns", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"topic"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "sqs", Name: "AddPermission", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "ChangeMessageVisibility", AccessLevel: AccessWrite, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "ChangeMessageVisibilityBatch", AccessLevel: AccessWrite, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "CreateQueue", AccessLevel: AccessWrite, ResourceTypes: []string{"queue"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "sqs", Name: "DeleteMessage", AccessLevel: AccessWrite, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "DeleteMessageBatch", AccessLevel: AccessWrite, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "DeleteQueue", AccessLevel: AccessWrite, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "GetQueueAttributes", AccessLevel: AccessRead, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "GetQueueUrl", AccessLevel: AccessRead, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "ListDeadLetterSourceQueues", AccessLevel: AccessRead, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "ListQueueTags", AccessLevel: AccessRead, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "ListQueues", AccessLevel: AccessList},
+	{Service: "sqs", Name: "PurgeQueue", AccessLevel: AccessWrite, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "ReceiveMessage", AccessLevel: AccessRead, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "RemovePermission", AccessLevel: AccessPermissionsManagement, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "SendMessage", AccessLevel: AccessWrite, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "SendMessageBatch", AccessLevel: AccessWrite, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "SetQueueAttributes", AccessLevel: AccessWrite, ResourceTypes: []string{"queue"}},
+	{Service: "sqs", Name: "TagQueue", AccessLevel: AccessTagging, ResourceTypes: []string{"queue"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "sqs", Name: "UntagQueue", AccessLevel: AccessTagging, ResourceTypes: []string{"queue"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "ssm", Name: "AddTagsToResource", AccessLevel: AccessTagging, ResourceTypes: []string{"parameter"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "ssm", Name: "DeleteParameter", AccessLevel: AccessWrite, ResourceTypes: []string{"parameter"}},
+	{Service: "ssm", Name: "DeleteParameters", AccessLevel: AccessWrite, ResourceTypes: []string{"parameter"}},
+	{Service: "ssm", Name: "DescribeDocument", AccessLevel: AccessRead, ResourceTypes: []string{"document"}},
+	{Service: "ssm", Name: "DescribeParameters", AccessLevel: AccessList},
+	{Service: "ssm", Name: "GetDocument", AccessLevel: AccessRead, ResourceTypes: []string{"document"}},
+	{Service: "ssm", Name: "GetParameter", AccessLevel: AccessRead, ResourceTypes: []string{"parameter"}},
+	{Service: "ssm", Name: "GetParameterHistory", AccessLevel: AccessRead, ResourceTypes: []string{"parameter"}},
+	{Service: "ssm", Name: "GetParameters", AccessLevel: AccessRead, ResourceTypes: []string{"parameter"}},
+	{Service: "ssm", Name: "GetParametersByPath", AccessLevel: AccessRead, ResourceTypes: []string{"parameter"}, ConditionKeys: []string{"ssm:Recursive"}},
+	{Service: "ssm", Name: "ListTagsForResource", AccessLevel: AccessRead, ResourceTypes: []string{"parameter"}},
+	{Service: "ssm", Name: "PutParameter", AccessLevel: AccessWrite, ResourceTypes: []string{"parameter"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "ssm:Overwrite", "ssm:Policies"}},
+	{Service: "ssm", Name: "RemoveTagsFromResource", AccessLevel: AccessTagging, ResourceTypes: []string{"parameter"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "ssm", Name: "SendCommand", AccessLevel: AccessWrite, ResourceTypes: []string{"document", "instance"}},
+	{Service: "ssm", Name: "StartSession", AccessLevel: AccessWrite, ResourceTypes: []string{"document", "instance"}, ConditionKeys: []string{"ssm:SessionDocumentAccessCheck"}},
+	{Service: "states", Name: "CreateStateMachine", AccessLevel: AccessWrite, ResourceTypes: []string{"statemachine"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "states", Name: "DeleteStateMachine", AccessLevel: AccessWrite, ResourceTypes: []string{"statemachine"}},
+	{Service: "states", Name: "DescribeExecution", AccessLevel: AccessRead, ResourceTypes: []string{"execution"}},
+	{Service: "states", Name: "DescribeStateMachine", AccessLevel: AccessRead, ResourceTypes: []string{"statemachine"}},
+	{Service: "states", Name: "GetActivityTask", AccessLevel: AccessWrite, ResourceTypes: []string{"activity"}},
+	{Service: "states", Name: "GetExecutionHistory", AccessLevel: AccessRead, ResourceTypes: []string{"execution"}},
+	{Service: "states", Name: "ListExecutions", AccessLevel: AccessList, ResourceTypes: []string{"statemachine"}},
+	{Service: "states", Name: "ListStateMachines", AccessLevel: AccessList},
+	{Service: "states", Name: "ListTagsForResource", AccessLevel: AccessList, ResourceTypes: []string{"activity", "statemachine"}},
+	{Service: "states", Name: "SendTaskFailure", AccessLevel: AccessWrite},
+	{Service: "states", Name: "SendTaskHeartbeat", AccessLevel: AccessWrite},
+	{Service: "states", Name: "SendTaskSuccess", AccessLevel: AccessWrite},
+	{Service: "states", Name: "StartExecution", AccessLevel: AccessWrite, ResourceTypes: []string{"statemachine"}},
+	{Service: "states", Name: "StartSyncExecution", AccessLevel: AccessWrite, ResourceTypes: []string{"statemachine"}},
+	{Service: "states", Name: "StopExecution", AccessLevel: AccessWrite, ResourceTypes: []string{"execution"}},
+	{Service: "states", Name: "TagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"activity", "statemachine"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "states", Name: "UntagResource", AccessLevel: AccessTagging, ResourceTypes: []string{"activity", "statemachine"}, ConditionKeys: []string{"aws:TagKeys"}},
+	{Service: "states", Name: "UpdateStateMachine", AccessLevel: AccessWrite, ResourceTypes: []string{"statemachine"}},
+	{Service: "sts", Name: "AssumeRole", AccessLevel: AccessWrite, ResourceTypes: []string{"role"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "sts:ExternalId", "sts:RoleSessionName", "sts:SourceIdentity", "sts:TransitiveTagKeys"}},
+	{Service: "sts", Name: "AssumeRoleWithSAML", AccessLevel: AccessWrite, ResourceTypes: []string{"role"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "saml:aud", "saml:iss", "saml:namequalifier", "saml:sub", "saml:sub_type", "sts:RoleSessionName", "sts:SourceIdentity", "sts:TransitiveTagKeys"}},
+	{Service: "sts", Name: "AssumeRoleWithWebIdentity", AccessLevel: AccessWrite, ResourceTypes: []string{"role"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "sts:RoleSessionName", "sts:SourceIdentity", "sts:TransitiveTagKeys"}},
+	{Service: "sts", Name: "DecodeAuthorizationMessage", AccessLevel: AccessWrite},
+	{Service: "sts", Name: "GetAccessKeyInfo", AccessLevel: AccessRead},
+	{Service: "sts", Name: "GetCallerIdentity", AccessLevel: AccessRead},
+	{Service: "sts", Name: "GetFederationToken", AccessLevel: AccessRead, ResourceTypes: []string{"user"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys"}},
+	{Service: "sts", Name: "GetSessionToken", AccessLevel: AccessRead},
+	{Service: "sts", Name: "SetSourceIdentity", AccessLevel: AccessWrite, ResourceTypes: []string{"role", "user"}, ConditionKeys: []string{"sts:SourceIdentity"}},
+	{Service: "sts", Name: "TagSession", AccessLevel: AccessTagging, ResourceTypes: []string{"role", "user"}, ConditionKeys: []string{"aws:RequestTag/${TagKey}", "aws:TagKeys", "sts:TransitiveTagKeys"}},
+	{Service: "textract", Name: "AnalyzeDocument", AccessLevel: AccessRead},
+	{Service: "textract", Name: "AnalyzeExpense", AccessLevel: AccessRead},
+	{Service: "textract", Name: "AnalyzeID", AccessLevel: AccessRead},
+	{Service: "textract", Name: "DetectDocumentText", AccessLevel: AccessRead},
+	{Service: "textract", Name: "GetDocumentAnalysis", AccessLevel: AccessRead},
+	{Service: "textract", Name: "GetDocumentTextDetection", AccessLevel: AccessRead},
+	{Service: "textract", Name: "GetExpenseAnalysis", AccessLevel: AccessRead},
+	{Service: "textract", Name: "StartDocumentAnalysis", AccessLevel: AccessWrite},
+	{Service: "textract", Name: "StartDocumentTextDetection", AccessLevel: AccessWrite},
+	{Service: "textract", Name: "StartExpenseAnalysis", AccessLevel: AccessWrite},
+	{Service: "xray", Name: "GetSamplingRules", AccessLevel: AccessRead},
+	{Service: "xray", Name: "GetSamplingStatisticSummaries", AccessLevel: AccessRead},
+	{Service: "xray", Name: "GetSamplingTargets", AccessLevel: AccessRead},
+	{Service: "xray", Name: "PutTelemetryRecords", AccessLevel: AccessWrite},
+	{Service: "xray", Name: "PutTraceSegments", AccessLevel: AccessWrite},
+}
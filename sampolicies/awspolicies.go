@@ -1,15 +1,25 @@
 package sampolicies
 
-// AddExecuteAPI allows the IAM role to execute API invocations
+// AddExecuteAPI allows anyone to execute API invocations. It adds a statement
+// with a Principal, so it can only be used in a factory that builds a
+// ResourcePolicy, like the policy of an API Gateway REST API.
 func (f *Factory) AddExecuteAPI() {
-	policy := `{ "Action": "execute-api:Invoke", "Resource": "execute-api:/*", "Principal": "*", "Effect": "Allow" }`
-	f.policies = append(f.policies, policy)
+	f.addPolicy("ExecuteAPI", nil, Statement{
+		Effect:    EffectAllow,
+		Principal: Principal{"AWS": Value{"*"}},
+		Action:    Value{"execute-api:Invoke"},
+		Resource:  Value{"execute-api:/*"},
+	})
 }
 
-// AddAssumeRoleLambda allows AWS Lambda to assume the role and use AWS services
+// AddAssumeRoleLambda allows AWS Lambda to assume the role and use AWS
+// services. It can only be used in a factory that builds a TrustPolicy.
 func (f *Factory) AddAssumeRoleLambda() {
-	policy := `{ "Action": "sts:AssumeRole", "Principal": { "Service": "lambda.amazonaws.com" }, "Effect": "Allow" }`
-	f.policies = append(f.policies, policy)
+	f.addPolicy("AssumeRoleLambda", nil, Statement{
+		Effect:    EffectAllow,
+		Principal: Principal{"Service": Value{servicePrincipal(ServiceLambda)}},
+		Action:    Value{"sts:AssumeRole"},
+	})
 }
 
 // AssumeRoleLambda returns an IAM policy document that allows the IAM role to be assumed by AWS Lambda
@@ -0,0 +1,305 @@
+package sampolicies
+
+//go:generate go run ../cmd/catalog-generator -input ../cmd/action_catalog.json -output actions.go
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// UnknownActionErr is returned when actions of a service in the action catalog don't exist
+	UnknownActionErr = "unknown actions: %s"
+)
+
+// AccessLevel is the access level AWS assigns to an action, which describes
+// what the action allows.
+type AccessLevel string
+
+const (
+	// AccessList allows listing resources, like s3:ListBucket
+	AccessList AccessLevel = "List"
+
+	// AccessRead allows reading the contents and attributes of resources,
+	// like s3:GetObject
+	AccessRead AccessLevel = "Read"
+
+	// AccessWrite allows creating, changing, and deleting resources, like
+	// s3:PutObject
+	AccessWrite AccessLevel = "Write"
+
+	// AccessPermissionsManagement allows changing the permissions of
+	// resources, like s3:PutBucketPolicy
+	AccessPermissionsManagement AccessLevel = "Permissions management"
+
+	// AccessTagging allows changing the tags of resources, like
+	// s3:PutObjectTagging
+	AccessTagging AccessLevel = "Tagging"
+
+	// AccessUnknown is used for actions of services that aren't in the
+	// action catalog
+	AccessUnknown AccessLevel = "Unknown"
+)
+
+// accessLevelOrder is the order in which access levels are reported
+var accessLevelOrder = []AccessLevel{AccessList, AccessRead, AccessWrite, AccessPermissionsManagement, AccessTagging, AccessUnknown}
+
+// ActionInfo describes a single action in the action catalog.
+type ActionInfo struct {
+	// Service is the service prefix of the action, like s3
+	Service string
+
+	// Name is the name of the action without the service prefix, like
+	// GetObject
+	Name string
+
+	// AccessLevel is the access level of the action
+	AccessLevel AccessLevel
+
+	// ResourceTypes are the resource types the action can be limited to. An
+	// action without resource types only supports "Resource": "*".
+	ResourceTypes []string
+
+	// ConditionKeys are the service specific condition keys the action
+	// supports, next to the global condition keys
+	ConditionKeys []string
+}
+
+// String returns the action with its service prefix, like s3:GetObject
+func (a ActionInfo) String() string {
+	return fmt.Sprintf("%s:%s", a.Service, a.Name)
+}
+
+// CatalogServices returns the sorted prefixes of the services in the action
+// catalog. The catalog is a snapshot of the services used by the policy
+// templates, together with IAM, STS, and a few other services that are
+// often used next to them. It is a hand-curated subset of the AWS Service
+// Authorization Reference, so it can lag behind the actions AWS adds.
+func CatalogServices() []string {
+	prefixes := make([]string, 0, len(catalogServices))
+	for prefix := range catalogServices {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	return prefixes
+}
+
+// CatalogServiceName returns the name of the service with the prefix, like
+// Amazon S3 for s3, if the service is in the action catalog.
+func CatalogServiceName(prefix string) (string, bool) {
+	name, ok := catalogServices[strings.ToLower(prefix)]
+	return name, ok
+}
+
+// LookupAction returns the action from the action catalog. Actions are case
+// insensitive, so s3:getobject finds s3:GetObject.
+func LookupAction(action string) (ActionInfo, bool) {
+	for _, a := range catalogActions {
+		if strings.EqualFold(a.String(), action) {
+			return a, true
+		}
+	}
+	return ActionInfo{}, false
+}
+
+// ExpandActions returns the actions in the action catalog that match one of
+// the patterns, like sqs:SendMessage* for sqs:SendMessage and
+// sqs:SendMessageBatch. The actions are sorted by service and name.
+func ExpandActions(patterns ...string) []ActionInfo {
+	res := make([]*regexp.Regexp, len(patterns))
+	for idx, pattern := range patterns {
+		res[idx] = wildcardRegexp(pattern, true)
+	}
+
+	out := make([]ActionInfo, 0)
+	for _, a := range catalogActions {
+		for _, re := range res {
+			if re.MatchString(a.String()) {
+				out = append(out, a)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// ValidateActions checks that the actions, which can have wildcards, match
+// at least one action in the action catalog. Actions of services that aren't
+// in the catalog are skipped, as the catalog doesn't cover every AWS service.
+func ValidateActions(actions ...string) error {
+	unknown := make([]string, 0)
+	for _, action := range actions {
+		if !isCatalogService(action) {
+			continue
+		}
+		if len(ExpandActions(action)) == 0 {
+			unknown = append(unknown, action)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf(UnknownActionErr, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// ValidateActions creates the policy document and checks the actions of its
+// statements like ValidateActions.
+func (f *Factory) ValidateActions() error {
+	doc, err := f.GetPolicyDocument()
+	if err != nil {
+		return err
+	}
+	return ValidateActions(documentActions(doc.Statement)...)
+}
+
+// ValidateTemplateActions checks the actions of every template the factory
+// knows, including the custom templates, like ValidateActions. The error
+// names the first template with an unknown action.
+func (f *Factory) ValidateTemplateActions() error {
+	for _, t := range f.Templates() {
+		if err := ValidateActions(documentActions(t.Statements)...); err != nil {
+			return fmt.Errorf("template %s: %s", t.Name, err.Error())
+		}
+	}
+	return nil
+}
+
+// AccessSummary maps the services of a policy to the actions it allows,
+// grouped by access level.
+type AccessSummary map[string]map[AccessLevel][]string
+
+// AccessSummary returns the actions the Allow statements of the policy
+// document grant, grouped by service and access level. Wildcards and
+// NotAction are expanded using the action catalog. Actions of services that
+// aren't in the catalog are listed with AccessUnknown. Resources, conditions,
+// and Deny statements aren't taken into account.
+func (d *PolicyDocument) AccessSummary() AccessSummary {
+	summary := make(AccessSummary)
+	seen := make(map[string]bool)
+
+	add := func(service string, level AccessLevel, action string) {
+		if seen[strings.ToLower(action)] {
+			return
+		}
+		seen[strings.ToLower(action)] = true
+
+		if summary[service] == nil {
+			summary[service] = make(map[AccessLevel][]string)
+		}
+		summary[service][level] = append(summary[service][level], action)
+	}
+
+	for _, s := range d.Statement {
+		if s.Effect != EffectAllow {
+			continue
+		}
+
+		if len(s.NotAction) > 0 {
+			excluded := make(map[string]bool)
+			for _, a := range ExpandActions(s.NotAction...) {
+				excluded[a.String()] = true
+			}
+			for _, a := range catalogActions {
+				if !excluded[a.String()] {
+					add(a.Service, a.AccessLevel, a.String())
+				}
+			}
+			continue
+		}
+
+		for _, action := range s.Action {
+			if !isCatalogService(action) {
+				add(actionService(action), AccessUnknown, action)
+				continue
+			}
+			for _, a := range ExpandActions(action) {
+				add(a.Service, a.AccessLevel, a.String())
+			}
+		}
+	}
+
+	for _, levels := range summary {
+		for _, actions := range levels {
+			sort.Strings(actions)
+		}
+	}
+	return summary
+}
+
+// AccessSummary creates the policy document and summarizes it like
+// PolicyDocument.AccessSummary.
+func (f *Factory) AccessSummary() (AccessSummary, error) {
+	doc, err := f.GetPolicyDocument()
+	if err != nil {
+		return nil, err
+	}
+	return doc.AccessSummary(), nil
+}
+
+// String returns a line per service with the number of actions per access
+// level, like "s3: List (1), Read (2), Write (1)"
+func (s AccessSummary) String() string {
+	services := make([]string, 0, len(s))
+	for service := range s {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+
+	lines := make([]string, len(services))
+	for idx, service := range services {
+		levels := make([]string, 0)
+		for _, level := range accessLevelOrder {
+			if actions := s[service][level]; len(actions) > 0 {
+				levels = append(levels, fmt.Sprintf("%s (%d)", level, len(actions)))
+			}
+		}
+		lines[idx] = fmt.Sprintf("%s: %s", service, strings.Join(levels, ", "))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// isCatalogService returns true if the service of the action is in the
+// action catalog. The action * belongs to every service.
+func isCatalogService(action string) bool {
+	if action == "*" {
+		return true
+	}
+	_, ok := catalogServices[actionService(action)]
+	return ok
+}
+
+// isCatalogResourceType returns true if one of the actions of the service in
+// the action catalog can be limited to the resource type, like table for
+// dynamodb. Resource types are case insensitive.
+func isCatalogResourceType(service string, resourceType string) bool {
+	for _, a := range catalogActions {
+		if !strings.EqualFold(a.Service, service) {
+			continue
+		}
+		for _, t := range a.ResourceTypes {
+			if strings.EqualFold(t, resourceType) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// actionService returns the lowercase service prefix of the action
+func actionService(action string) string {
+	return strings.ToLower(strings.SplitN(action, ":", 2)[0])
+}
+
+// documentActions returns the actions in the Action and NotAction elements
+// of the statements
+func documentActions(statements []Statement) []string {
+	actions := make([]string, 0)
+	for _, s := range statements {
+		actions = append(actions, s.Action...)
+		actions = append(actions, s.NotAction...)
+	}
+	return actions
+}
@@ -0,0 +1,126 @@
+package sampolicies
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLookupAction(t *testing.T) {
+	tests := []struct {
+		action string
+		found  string
+		level  AccessLevel
+	}{
+		{"s3:GetObject", "s3:GetObject", AccessRead},
+		{"s3:getobject", "s3:GetObject", AccessRead},
+		{"SQS:SENDMESSAGE", "sqs:SendMessage", AccessWrite},
+		{"athena:CreateWorkGroup", "athena:CreateWorkGroup", AccessWrite},
+		{"sqs:SendMesage", "", ""},
+		{"nosuchservice:GetObject", "", ""},
+	}
+
+	for _, tt := range tests {
+		a, ok := LookupAction(tt.action)
+		if ok != (len(tt.found) > 0) {
+			t.Errorf("%s: got found %v", tt.action, ok)
+			continue
+		}
+		if ok && (a.String() != tt.found || a.AccessLevel != tt.level) {
+			t.Errorf("%s: got %s (%s), want %s (%s)", tt.action, a, a.AccessLevel, tt.found, tt.level)
+		}
+	}
+}
+
+func TestExpandActions(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		actions  []string
+	}{
+		{[]string{"sqs:SendMessage*"}, []string{"sqs:SendMessage", "sqs:SendMessageBatch"}},
+		{[]string{"SQS:sendmessage*"}, []string{"sqs:SendMessage", "sqs:SendMessageBatch"}},
+		{[]string{"sqs:SendMessage"}, []string{"sqs:SendMessage"}},
+		{[]string{"sqs:*Permission", "sns:Publish"}, []string{"sns:Publish", "sqs:AddPermission", "sqs:RemovePermission"}},
+		{[]string{"sqs:SendMessag?"}, []string{"sqs:SendMessage"}},
+		{[]string{"sqs:Nothing*"}, []string{}},
+	}
+
+	for _, tt := range tests {
+		got := make([]string, 0)
+		for _, a := range ExpandActions(tt.patterns...) {
+			got = append(got, a.String())
+		}
+		if !reflect.DeepEqual(got, tt.actions) {
+			t.Errorf("%v: got %v, want %v", tt.patterns, got, tt.actions)
+		}
+	}
+}
+
+func TestValidateActions(t *testing.T) {
+	tests := []struct {
+		actions []string
+		unknown string
+	}{
+		{[]string{"sqs:SendMessage", "s3:Get*", "*"}, ""},
+		{[]string{"sqs:SendMesage"}, "sqs:SendMesage"},
+		{[]string{"sqs:SendMessage", "sqs:SendMesage", "s3:GetObjekt"}, "sqs:SendMesage, s3:GetObjekt"},
+		{[]string{"nosuchservice:Anything"}, ""},
+	}
+
+	for _, tt := range tests {
+		err := ValidateActions(tt.actions...)
+		switch {
+		case len(tt.unknown) == 0 && err != nil:
+			t.Errorf("%v: unexpected error %s", tt.actions, err)
+		case len(tt.unknown) > 0 && (err == nil || !strings.HasSuffix(err.Error(), tt.unknown)):
+			t.Errorf("%v: got error %v, want it to name %s", tt.actions, err, tt.unknown)
+		}
+	}
+}
+
+func TestAccessSummary(t *testing.T) {
+	doc := &PolicyDocument{
+		Version: PolicyVersion,
+		Statement: []Statement{
+			{Effect: EffectAllow, Action: Value{"sqs:SendMessage*", "sqs:ReceiveMessage", "sqs:ListQueues", "sqs:TagQueue"}, Resource: Value{"*"}},
+			{Effect: EffectAllow, Action: Value{"SQS:sendmessage", "nosuchservice:Do"}, Resource: Value{"*"}},
+			{Effect: EffectDeny, Action: Value{"sqs:DeleteQueue"}, Resource: Value{"*"}},
+		},
+	}
+
+	summary := doc.AccessSummary()
+	want := AccessSummary{
+		"sqs": {
+			AccessList:    {"sqs:ListQueues"},
+			AccessRead:    {"sqs:ReceiveMessage"},
+			AccessWrite:   {"sqs:SendMessage", "sqs:SendMessageBatch"},
+			AccessTagging: {"sqs:TagQueue"},
+		},
+		"nosuchservice": {
+			AccessUnknown: {"nosuchservice:Do"},
+		},
+	}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("got %v, want %v", summary, want)
+	}
+
+	lines := "nosuchservice: Unknown (1)\nsqs: List (1), Read (1), Write (2), Tagging (1)"
+	if got := summary.String(); got != lines {
+		t.Errorf("got summary\n%s\nwant\n%s", got, lines)
+	}
+}
+
+func TestAccessSummaryNotAction(t *testing.T) {
+	doc := &PolicyDocument{
+		Version:   PolicyVersion,
+		Statement: []Statement{{Effect: EffectAllow, NotAction: Value{"sqs:*"}, Resource: Value{"*"}}},
+	}
+
+	summary := doc.AccessSummary()
+	if _, ok := summary["sqs"]; ok {
+		t.Error("NotAction sqs:* shouldn't grant sqs actions")
+	}
+	if len(summary["sns"][AccessWrite]) == 0 {
+		t.Error("NotAction sqs:* should grant the sns write actions")
+	}
+}
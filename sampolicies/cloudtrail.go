@@ -0,0 +1,677 @@
+package sampolicies
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// InvalidCloudTrailErr is returned when a CloudTrail log can't be parsed
+	InvalidCloudTrailErr = "invalid CloudTrail log %s: %s"
+)
+
+// ObservedCall is an action that was called on a resource, as recorded in a
+// CloudTrail log.
+type ObservedCall struct {
+	// Action is the IAM action of the call, like s3:GetObject
+	Action string
+
+	// Resource is the ARN of the resource of the call, or * if the event has
+	// no resource
+	Resource string
+
+	// Count is the number of times the action was called on the resource
+	Count int
+}
+
+// Activity is the set of calls a role made, read from CloudTrail logs.
+type Activity struct {
+	// Calls are the calls that succeeded, sorted by action and resource
+	Calls []ObservedCall
+
+	// Denied are the calls that failed because they weren't allowed
+	Denied []ObservedCall
+
+	// From and To are the times of the first and last event
+	From time.Time
+	To   time.Time
+}
+
+// cloudTrailLog is the format of the CloudTrail log files, which hold the
+// events in a Records element
+type cloudTrailLog struct {
+	Records []cloudTrailEvent `json:"Records"`
+}
+
+// cloudTrailEvent has the elements of a CloudTrail event that are needed to
+// find the action and resource of a call
+type cloudTrailEvent struct {
+	EventTime    time.Time `json:"eventTime"`
+	EventSource  string    `json:"eventSource"`
+	EventName    string    `json:"eventName"`
+	ErrorCode    string    `json:"errorCode"`
+	UserIdentity struct {
+		ARN            string `json:"arn"`
+		SessionContext struct {
+			SessionIssuer struct {
+				ARN string `json:"arn"`
+			} `json:"sessionIssuer"`
+		} `json:"sessionContext"`
+	} `json:"userIdentity"`
+	Resources []struct {
+		ARN       string `json:"ARN"`
+		ARNPrefix string `json:"ARNPrefix"`
+		Type      string `json:"type"`
+	} `json:"resources"`
+	RequestParameters map[string]interface{} `json:"requestParameters"`
+}
+
+// eventSourceServices maps the event sources whose name differs from the
+// service prefix of their actions
+var eventSourceServices = map[string]string{
+	"email.amazonaws.com":            "ses",
+	"monitoring.amazonaws.com":       "cloudwatch",
+	"pinpoint.amazonaws.com":         "mobiletargeting",
+	"streams.dynamodb.amazonaws.com": "dynamodb",
+}
+
+// eventActions maps the events whose name differs from the action that
+// allows them
+var eventActions = map[string]string{
+	"lambda:Invoke":              "lambda:InvokeFunction",
+	"s3:CompleteMultipartUpload": "s3:PutObject",
+	"s3:CopyObject":              "s3:PutObject",
+	"s3:CreateMultipartUpload":   "s3:PutObject",
+	"s3:DeleteObjects":           "s3:DeleteObject",
+	"s3:HeadBucket":              "s3:ListBucket",
+	"s3:HeadObject":              "s3:GetObject",
+	"s3:ListObjectVersions":      "s3:ListBucketVersions",
+	"s3:ListObjects":             "s3:ListBucket",
+	"s3:ListObjectsV2":           "s3:ListBucket",
+	"s3:UploadPart":              "s3:PutObject",
+	"s3:UploadPartCopy":          "s3:PutObject",
+}
+
+// deniedErrorCodes are the error codes of calls that weren't allowed
+var deniedErrorCodes = map[string]bool{
+	"AccessDenied":                 true,
+	"AccessDeniedException":        true,
+	"Client.UnauthorizedOperation": true,
+	"UnauthorizedOperation":        true,
+}
+
+// eventVersionRegexp matches the API version some services, like Lambda, add
+// to the event name
+var eventVersionRegexp = regexp.MustCompile(`\d{8}(v\d+)?$`)
+
+// queueURLRegexp matches the URL of an SQS queue
+var queueURLRegexp = regexp.MustCompile(`^https://(?:sqs\.)?([a-z0-9-]+)\.(?:queue\.)?amazonaws\.com(?:\.cn)?/(\d{12})/([^/]+)$`)
+
+// ReadCloudTrail reads CloudTrail log files, which can be gzipped like the
+// files CloudTrail delivers to S3, and returns the calls made by the role
+// with the ARN. If roleArn is empty, all calls are returned.
+func ReadCloudTrail(roleArn string, paths ...string) (*Activity, error) {
+	logs := make([][]byte, len(paths))
+	for idx, path := range paths {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if bytes.HasPrefix(data, []byte{0x1f, 0x8b}) {
+			r, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return nil, fmt.Errorf(InvalidCloudTrailErr, path, err.Error())
+			}
+			if data, err = ioutil.ReadAll(r); err != nil {
+				return nil, fmt.Errorf(InvalidCloudTrailErr, path, err.Error())
+			}
+		}
+		logs[idx] = data
+	}
+	return ParseCloudTrail(roleArn, logs...)
+}
+
+// ParseCloudTrail parses CloudTrail logs like ReadCloudTrail. Each log is
+// either an object with a Records element, or an array of events.
+func ParseCloudTrail(roleArn string, logs ...[]byte) (*Activity, error) {
+	calls := make(map[ObservedCall]int)
+	denied := make(map[ObservedCall]int)
+	activity := &Activity{}
+
+	for idx, data := range logs {
+		var log cloudTrailLog
+		if err := json.Unmarshal(data, &log); err != nil {
+			if err := json.Unmarshal(data, &log.Records); err != nil {
+				return nil, fmt.Errorf(InvalidCloudTrailErr, fmt.Sprintf("%d", idx), err.Error())
+			}
+		}
+
+		for _, e := range log.Records {
+			if len(roleArn) > 0 && e.UserIdentity.SessionContext.SessionIssuer.ARN != roleArn && e.UserIdentity.ARN != roleArn {
+				continue
+			}
+
+			action := e.action()
+			if len(action) == 0 {
+				continue
+			}
+
+			if activity.From.IsZero() || e.EventTime.Before(activity.From) {
+				activity.From = e.EventTime
+			}
+			if e.EventTime.After(activity.To) {
+				activity.To = e.EventTime
+			}
+
+			target := calls
+			if deniedErrorCodes[e.ErrorCode] {
+				target = denied
+			}
+			for _, resource := range e.resources(action) {
+				target[ObservedCall{Action: action, Resource: resource}]++
+			}
+		}
+	}
+
+	activity.Calls = sortedCalls(calls)
+	activity.Denied = sortedCalls(denied)
+	return activity, nil
+}
+
+// action returns the IAM action that allows the event, using the name of the
+// action in the action catalog if it's there
+func (e cloudTrailEvent) action() string {
+	if len(e.EventSource) == 0 || len(e.EventName) == 0 {
+		return ""
+	}
+
+	service, ok := eventSourceServices[e.EventSource]
+	if !ok {
+		service = strings.SplitN(e.EventSource, ".", 2)[0]
+	}
+
+	action := fmt.Sprintf("%s:%s", service, eventVersionRegexp.ReplaceAllString(e.EventName, ""))
+	if alias, ok := eventActions[action]; ok {
+		action = alias
+	}
+
+	if a, ok := LookupAction(action); ok {
+		return a.String()
+	}
+	return action
+}
+
+// resources returns the ARNs of the resources of the event. If the action
+// can be limited to resource types, only the resources of those types are
+// returned. An event without resources returns *.
+func (e cloudTrailEvent) resources(action string) []string {
+	info, _ := LookupAction(action)
+	types := make(map[string]bool, len(info.ResourceTypes))
+	for _, t := range info.ResourceTypes {
+		types[strings.ToLower(t)] = true
+	}
+
+	all := make([]string, 0)
+	typed := make([]string, 0)
+	for _, r := range e.Resources {
+		arn := r.ARN
+		if len(arn) == 0 && len(r.ARNPrefix) > 0 {
+			arn = r.ARNPrefix + "*"
+		}
+		if len(arn) == 0 {
+			continue
+		}
+
+		all = append(all, arn)
+		parts := strings.Split(r.Type, "::")
+		if types[strings.ToLower(parts[len(parts)-1])] {
+			typed = append(typed, arn)
+		}
+	}
+
+	// SQS events don't list their queue as a resource
+	if url, ok := e.RequestParameters["queueUrl"].(string); ok && len(all) == 0 {
+		if m := queueURLRegexp.FindStringSubmatch(url); m != nil {
+			all = append(all, fmt.Sprintf("arn:%s:sqs:%s:%s:%s", PartitionForRegion(m[1]), m[1], m[2], m[3]))
+		}
+	}
+
+	switch {
+	case len(typed) > 0:
+		return typed
+	case len(all) > 0:
+		return all
+	}
+	return []string{"*"}
+}
+
+// sortedCalls returns the calls with their counts, sorted by action and
+// resource
+func sortedCalls(calls map[ObservedCall]int) []ObservedCall {
+	out := make([]ObservedCall, 0, len(calls))
+	for call, count := range calls {
+		call.Count = count
+		out = append(out, call)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Action != out[j].Action {
+			return out[i].Action < out[j].Action
+		}
+		return out[i].Resource < out[j].Resource
+	})
+	return out
+}
+
+// TemplateUse is a template, with the values of its parameters, that covers
+// observed calls.
+type TemplateUse struct {
+	Template Template
+
+	// Params are the values of the template parameters, keyed by name
+	Params map[string]string
+
+	// Calls are the observed calls the template covers
+	Calls []ObservedCall
+}
+
+// Usage returns the method call that adds the template with its parameters
+// to a Factory, like AddS3ReadPolicy("my-bucket"), or the call to Add for a
+// custom template.
+func (u TemplateUse) Usage() string {
+	params := make([]string, len(u.Template.Parameters))
+
+	if _, ok := registry[u.Template.Name]; !ok {
+		for idx, p := range u.Template.Parameters {
+			params[idx] = fmt.Sprintf("%q: %q", p.Name, u.Params[p.Name])
+		}
+		return fmt.Sprintf("Add(%q, map[string]string{%s})", u.Template.Name, strings.Join(params, ", "))
+	}
+
+	for idx, p := range u.Template.Parameters {
+		params[idx] = fmt.Sprintf("%q", u.Params[p.Name])
+	}
+	return fmt.Sprintf("Add%s(%s)", u.Template.Name, strings.Join(params, ", "))
+}
+
+// PolicyRecommendation is the set of templates and custom statements that
+// covers the calls of an Activity.
+type PolicyRecommendation struct {
+	Templates  []TemplateUse
+	Statements []Statement
+}
+
+// Apply adds the templates and custom statements of the recommendation to
+// the factory.
+func (r PolicyRecommendation) Apply(f *Factory) error {
+	for _, t := range r.Templates {
+		if err := f.Add(t.Template.Name, t.Params); err != nil {
+			return err
+		}
+	}
+	if len(r.Statements) > 0 {
+		f.AddStatements(r.Statements...)
+	}
+	return nil
+}
+
+// Recommend returns the smallest set of templates the factory knows,
+// including its custom templates, that covers the calls of the activity,
+// and custom statements for the calls no template covers. Templates are
+// picked by the number of calls they cover and, for the same number of
+// calls, by the number of actions they grant. The template parameters are
+// taken from the ARNs of the calls, and the partition, region, and account
+// of the factory must match the ARNs for a template to cover a call.
+func (f *Factory) Recommend(activity *Activity) (PolicyRecommendation, error) {
+	if len(f.accountID) == 0 {
+		return PolicyRecommendation{}, errors.New(AccountIDMissingErr)
+	}
+	if len(f.region) == 0 {
+		return PolicyRecommendation{}, errors.New(RegionMissingErr)
+	}
+	if len(f.Partition()) == 0 {
+		return PolicyRecommendation{}, errors.New(PartitionMissingErr)
+	}
+
+	values := f.pseudoParameterValues()
+	candidates := f.templateCandidates(activity.Calls, values)
+
+	uncovered := make(map[int]bool, len(activity.Calls))
+	for idx := range activity.Calls {
+		uncovered[idx] = true
+	}
+
+	recommendation := PolicyRecommendation{Templates: make([]TemplateUse, 0)}
+	for len(uncovered) > 0 {
+		best, bestCalls := -1, []int(nil)
+		for idx, c := range candidates {
+			covered := make([]int, 0)
+			for _, call := range c.calls {
+				if uncovered[call] {
+					covered = append(covered, call)
+				}
+			}
+			if len(covered) == 0 {
+				continue
+			}
+			if best < 0 || len(covered) > len(bestCalls) || (len(covered) == len(bestCalls) && c.actions < candidates[best].actions) {
+				best, bestCalls = idx, covered
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		use := TemplateUse{Template: candidates[best].template, Params: candidates[best].params, Calls: make([]ObservedCall, len(bestCalls))}
+		for idx, call := range bestCalls {
+			use.Calls[idx] = activity.Calls[call]
+			delete(uncovered, call)
+		}
+		recommendation.Templates = append(recommendation.Templates, use)
+	}
+
+	// The remaining calls are grouped into a statement per set of resources
+	actions := make(map[string][]string)
+	order := make([]string, 0)
+	for idx, call := range activity.Calls {
+		if !uncovered[idx] {
+			continue
+		}
+		if _, ok := actions[call.Action]; !ok {
+			order = append(order, call.Action)
+		}
+		actions[call.Action] = append(actions[call.Action], call.Resource)
+	}
+
+	statements := make(map[string]int)
+	for _, action := range order {
+		resources := Value(actions[action]).sorted()
+		key := strings.Join(resources, "\x00")
+		if idx, ok := statements[key]; ok {
+			recommendation.Statements[idx].Action = append(recommendation.Statements[idx].Action, action)
+			continue
+		}
+		statements[key] = len(recommendation.Statements)
+		recommendation.Statements = append(recommendation.Statements, Statement{
+			Effect:   EffectAllow,
+			Action:   Value{action},
+			Resource: resources,
+		})
+	}
+
+	return recommendation, nil
+}
+
+// templateCandidate is a template with the values of its parameters, and the
+// indexes of the calls it covers
+type templateCandidate struct {
+	template Template
+	params   map[string]string
+	calls    []int
+	actions  int
+}
+
+// templateCandidates returns every template, with parameters taken from the
+// ARNs of the calls, that covers at least one call
+func (f *Factory) templateCandidates(calls []ObservedCall, values map[string]string) []templateCandidate {
+	candidates := make([]templateCandidate, 0)
+	seen := make(map[string]bool)
+
+	for _, t := range f.Templates() {
+		for _, call := range calls {
+			for _, params := range templateParameters(t, call, values) {
+				// fmt prints maps sorted by key
+				key := fmt.Sprintf("%s%v", t.Name, params)
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				c := templateCandidate{template: t, params: params, actions: templateActions(t)}
+				c.calls = coveredCalls(t, params, calls, values)
+				if len(c.calls) > 0 {
+					candidates = append(candidates, c)
+				}
+			}
+		}
+	}
+
+	// Sort the candidates so the same activity always gets the same
+	// recommendation
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].template.Name < candidates[j].template.Name
+	})
+	return mergeCandidates(candidates)
+}
+
+// mergeCandidates drops the candidates whose calls are all covered by another
+// candidate of the same template, so only the most general parameter values
+// of each template are kept. Of candidates that cover the same calls, the
+// first one is kept.
+func mergeCandidates(candidates []templateCandidate) []templateCandidate {
+	out := make([]templateCandidate, 0, len(candidates))
+
+	for i, c := range candidates {
+		merged := false
+		for j, other := range candidates {
+			if i == j || c.template.Name != other.template.Name || !containsCalls(other.calls, c.calls) {
+				continue
+			}
+			if len(other.calls) > len(c.calls) || j < i {
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// containsCalls returns true if every call in subset is in calls
+func containsCalls(calls []int, subset []int) bool {
+	set := make(map[int]bool, len(calls))
+	for _, call := range calls {
+		set[call] = true
+	}
+	for _, call := range subset {
+		if !set[call] {
+			return false
+		}
+	}
+	return true
+}
+
+// templateParameters returns the values of the template parameters that
+// make a statement of the template grant the call, taken from the ARN of
+// the call. Templates without parameters only return an empty set.
+func templateParameters(t Template, call ObservedCall, values map[string]string) []map[string]string {
+	out := make([]map[string]string, 0)
+
+	for _, s := range t.Statements {
+		if s.Effect != EffectAllow || !s.grants(call.Action) {
+			continue
+		}
+
+		for _, pattern := range s.Resource {
+			params, ok := captureParameters(pattern, call.Resource, values)
+			if !ok {
+				continue
+			}
+
+			complete := true
+			for _, p := range t.Parameters {
+				if _, ok := params[p.Name]; !ok {
+					complete = false
+				}
+			}
+			if complete {
+				out = append(out, params)
+			}
+		}
+	}
+	return out
+}
+
+// captureParameters matches the resource against the pattern of a template
+// resource, and returns the values of the placeholders in the pattern. The
+// placeholders in values must match their value, and the other placeholders
+// match a single segment of the ARN.
+func captureParameters(pattern string, resource string, values map[string]string) (map[string]string, bool) {
+	var b strings.Builder
+	names := make([]string, 0)
+
+	last := 0
+	for _, loc := range placeholderRegexp.FindAllStringSubmatchIndex(pattern, -1) {
+		b.WriteString(valueExpr(pattern[last:loc[0]]))
+		last = loc[1]
+
+		name := pattern[loc[2]:loc[3]]
+		if val, ok := values[name]; ok {
+			b.WriteString(regexp.QuoteMeta(val))
+			continue
+		}
+		// A placeholder captures a single segment of the ARN, so the key
+		// of an object doesn't end up in the name of its bucket. Only a
+		// placeholder that is the whole pattern, like ${SecretArn},
+		// captures the whole ARN.
+		if loc[0] == 0 && loc[1] == len(pattern) {
+			b.WriteString("(.+)")
+		} else {
+			b.WriteString("([^/:]+)")
+		}
+		names = append(names, name)
+	}
+	b.WriteString(valueExpr(pattern[last:]))
+
+	// A call without a resource can only be covered by a pattern without
+	// parameters
+	if resource == "*" && len(names) > 0 {
+		return nil, false
+	}
+
+	m := compileWildcard(b.String(), false).FindStringSubmatch(resource)
+	if m == nil {
+		return nil, false
+	}
+
+	params := make(map[string]string, len(names))
+	for idx, name := range names {
+		if val, ok := params[name]; ok && val != m[idx+1] {
+			return nil, false
+		}
+		params[name] = m[idx+1]
+	}
+	return params, true
+}
+
+// coveredCalls returns the indexes of the calls the template grants with the
+// parameters
+func coveredCalls(t Template, params map[string]string, calls []ObservedCall, values map[string]string) []int {
+	merged := make(map[string]string, len(values)+len(params))
+	for key, val := range values {
+		merged[key] = val
+	}
+	for key, val := range params {
+		merged[key] = val
+	}
+
+	statements := make([]Statement, 0, len(t.Statements))
+	for _, s := range t.Statements {
+		resolved, missing := substitute(s, merged)
+		if len(missing) == 0 && resolved.Effect == EffectAllow {
+			statements = append(statements, resolved)
+		}
+	}
+
+	covered := make([]int, 0)
+	for idx, call := range calls {
+		for _, s := range statements {
+			if s.grants(call.Action) && matchAny(s.Resource, call.Resource, false, nil) {
+				covered = append(covered, idx)
+				break
+			}
+		}
+	}
+	return covered
+}
+
+// UnusedPermissions returns the actions the Allow statements of the policy
+// document grant, but that weren't called in the activity. Wildcards are
+// expanded using the action catalog: a wildcard is returned as is if none
+// of its actions were called, and otherwise the actions that weren't called
+// are returned. Resources and conditions aren't taken into account.
+func (d *PolicyDocument) UnusedPermissions(activity *Activity) []string {
+	used := make(map[string]bool)
+	for _, call := range activity.Calls {
+		used[strings.ToLower(call.Action)] = true
+	}
+
+	unused := make(map[string]bool)
+	for _, s := range d.Statement {
+		if s.Effect != EffectAllow {
+			continue
+		}
+
+		patterns := s.Action
+		if len(s.NotAction) > 0 {
+			patterns = Value{"*"}
+		}
+
+		for _, pattern := range patterns {
+			if !strings.ContainsAny(pattern, "*?") {
+				if !used[strings.ToLower(pattern)] {
+					unused[pattern] = true
+				}
+				continue
+			}
+
+			actions := make([]string, 0)
+			anyUsed := false
+			for _, a := range ExpandActions(pattern) {
+				if len(s.NotAction) > 0 && !s.grants(a.String()) {
+					continue
+				}
+				if used[strings.ToLower(a.String())] {
+					anyUsed = true
+					continue
+				}
+				actions = append(actions, a.String())
+			}
+
+			if !anyUsed {
+				unused[pattern] = true
+				continue
+			}
+			for _, a := range actions {
+				unused[a] = true
+			}
+		}
+	}
+
+	out := make([]string, 0, len(unused))
+	for action := range unused {
+		out = append(out, action)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// UnusedPermissions creates the policy document and returns the permissions
+// that weren't used like PolicyDocument.UnusedPermissions.
+func (f *Factory) UnusedPermissions(activity *Activity) ([]string, error) {
+	doc, err := f.GetPolicyDocument()
+	if err != nil {
+		return nil, err
+	}
+	return doc.UnusedPermissions(activity), nil
+}
@@ -0,0 +1,235 @@
+package sampolicies
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+const testRoleArn = "arn:aws:iam::123456789012:role/app"
+
+func TestReadCloudTrail(t *testing.T) {
+	activity, err := ReadCloudTrail(testRoleArn, "testdata/cloudtrail.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := []ObservedCall{
+		{Action: "lambda:InvokeFunction", Resource: "arn:aws:lambda:us-east-1:123456789012:function:worker", Count: 1},
+		{Action: "s3:GetObject", Resource: "arn:aws:s3:::my-bucket/dir/sub/key.txt", Count: 1},
+		{Action: "s3:GetObject", Resource: "arn:aws:s3:::my-bucket/other/key.txt", Count: 1},
+	}
+	if !reflect.DeepEqual(activity.Calls, calls) {
+		t.Errorf("got calls %v, want %v", activity.Calls, calls)
+	}
+
+	denied := []ObservedCall{{Action: "kms:Decrypt", Resource: "arn:aws:kms:us-east-1:123456789012:key/abc", Count: 1}}
+	if !reflect.DeepEqual(activity.Denied, denied) {
+		t.Errorf("got denied calls %v, want %v", activity.Denied, denied)
+	}
+}
+
+func TestRecommendNestedObjectKeys(t *testing.T) {
+	activity, err := ReadCloudTrail(testRoleArn, "testdata/cloudtrail.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1")
+	recommendation, err := f.Recommend(activity)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usages := make([]string, len(recommendation.Templates))
+	for idx, u := range recommendation.Templates {
+		usages[idx] = u.Usage()
+	}
+	want := []string{`AddS3ReadPolicy("my-bucket")`, `AddLambdaInvokePolicy("worker")`}
+	if !reflect.DeepEqual(usages, want) {
+		t.Errorf("got templates %v, want %v", usages, want)
+	}
+	if len(recommendation.Statements) != 0 {
+		t.Errorf("got custom statements %v, want none", recommendation.Statements)
+	}
+
+	// The recommended policy must allow every call
+	g := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1")
+	if err := recommendation.Apply(g); err != nil {
+		t.Fatal(err)
+	}
+	for _, call := range activity.Calls {
+		e, err := g.Evaluate(call.Action, call.Resource, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Decision != DecisionAllow {
+			t.Errorf("%s on %s is %s, want %s", call.Action, call.Resource, e.Decision, DecisionAllow)
+		}
+	}
+}
+
+func TestCaptureParameters(t *testing.T) {
+	values := map[string]string{"AWS::Partition": "aws"}
+
+	tests := []struct {
+		pattern  string
+		resource string
+		params   map[string]string
+		ok       bool
+	}{
+		{"arn:${AWS::Partition}:s3:::${BucketName}/*", "arn:aws:s3:::my-bucket/dir/sub/key.txt", map[string]string{"BucketName": "my-bucket"}, true},
+		{"arn:${AWS::Partition}:s3:::${BucketName}", "arn:aws:s3:::my-bucket/dir/key.txt", nil, false},
+		{"arn:${AWS::Partition}:s3:::${BucketName}", "arn:aws:s3:::my-bucket", map[string]string{"BucketName": "my-bucket"}, true},
+		{"arn:${AWS::Partition}:lambda:*:*:function:${FunctionName}*", "arn:aws:lambda:us-east-1:123456789012:function:worker", map[string]string{"FunctionName": "worker"}, true},
+		{"${SecretArn}", "arn:aws:secretsmanager:us-east-1:123456789012:secret:db", map[string]string{"SecretArn": "arn:aws:secretsmanager:us-east-1:123456789012:secret:db"}, true},
+		{"arn:${AWS::Partition}:s3:::${BucketName}", "*", nil, false},
+		{"arn:${AWS::Partition}:s3:::${BucketName}", "arn:aws-cn:s3:::my-bucket", nil, false},
+	}
+
+	for _, tt := range tests {
+		params, ok := captureParameters(tt.pattern, tt.resource, values)
+		if ok != tt.ok || (ok && !reflect.DeepEqual(params, tt.params)) {
+			t.Errorf("captureParameters(%q, %q) = %v, %v, want %v, %v", tt.pattern, tt.resource, params, ok, tt.params, tt.ok)
+		}
+	}
+}
+
+func TestRecommendPrefersFewestActions(t *testing.T) {
+	queue := func(name string, actions ...string) Template {
+		return Template{
+			Name:       name,
+			Parameters: []Parameter{{Name: "QueueName"}},
+			Statements: []Statement{{
+				Effect:   EffectAllow,
+				Action:   Value(actions),
+				Resource: Value{"arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${QueueName}"},
+			}},
+		}
+	}
+
+	f := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1")
+	for _, tmpl := range []Template{
+		// Sorted before the narrow template, so only the number of actions
+		// can make the narrow template win
+		queue("AQueueTagsAdminPolicy", "sqs:ListQueueTags", "sqs:TagQueue", "sqs:UntagQueue"),
+		queue("ZQueueTagsReadPolicy", "sqs:ListQueueTags"),
+		queue("QueueTagsAndAttributesPolicy", "sqs:ListQueueTags", "sqs:GetQueueAttributes", "sqs:TagQueue", "sqs:UntagQueue"),
+	} {
+		if err := f.RegisterTemplate(tmpl); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name  string
+		calls []ObservedCall
+		want  []string
+	}{
+		{
+			name:  "fewest actions for the same calls",
+			calls: []ObservedCall{{Action: "sqs:ListQueueTags", Resource: "arn:aws:sqs:us-east-1:123456789012:jobs", Count: 1}},
+			want:  []string{"ZQueueTagsReadPolicy"},
+		},
+		{
+			name: "most calls before fewest actions",
+			calls: []ObservedCall{
+				{Action: "sqs:ListQueueTags", Resource: "arn:aws:sqs:us-east-1:123456789012:jobs", Count: 1},
+				{Action: "sqs:TagQueue", Resource: "arn:aws:sqs:us-east-1:123456789012:jobs", Count: 1},
+			},
+			want: []string{"AQueueTagsAdminPolicy"},
+		},
+	}
+
+	for _, tt := range tests {
+		recommendation, err := f.Recommend(&Activity{Calls: tt.calls})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		names := make([]string, len(recommendation.Templates))
+		for idx, u := range recommendation.Templates {
+			names[idx] = u.Template.Name
+			if u.Params["QueueName"] != "jobs" {
+				t.Errorf("%s: got parameters %v for %s", tt.name, u.Params, u.Template.Name)
+			}
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("%s: got templates %v, want %v", tt.name, names, tt.want)
+		}
+	}
+}
+
+func TestUnusedPermissions(t *testing.T) {
+	activity := &Activity{Calls: []ObservedCall{
+		{Action: "sqs:SendMessage", Resource: "arn:aws:sqs:us-east-1:123456789012:jobs", Count: 3},
+		{Action: "s3:GetObject", Resource: "arn:aws:s3:::uploads/a.txt", Count: 1},
+	}}
+
+	tests := []struct {
+		name       string
+		statements []Statement
+		unused     []string
+	}{
+		{
+			name: "single actions",
+			statements: []Statement{
+				{Effect: EffectAllow, Action: Value{"SQS:sendmessage", "sqs:DeleteMessage"}, Resource: Value{"*"}},
+			},
+			unused: []string{"sqs:DeleteMessage"},
+		},
+		{
+			name: "wildcard with a used action",
+			statements: []Statement{
+				{Effect: EffectAllow, Action: Value{"sqs:SendMessage*"}, Resource: Value{"*"}},
+			},
+			unused: []string{"sqs:SendMessageBatch"},
+		},
+		{
+			name: "wildcard without a used action",
+			statements: []Statement{
+				{Effect: EffectAllow, Action: Value{"sns:Publish*", "sqs:Purge*"}, Resource: Value{"*"}},
+			},
+			unused: []string{"sns:Publish*", "sqs:Purge*"},
+		},
+		{
+			name: "deny statements",
+			statements: []Statement{
+				{Effect: EffectAllow, Action: Value{"s3:GetObject"}, Resource: Value{"*"}},
+				{Effect: EffectDeny, Action: Value{"s3:DeleteObject"}, Resource: Value{"*"}},
+			},
+			unused: []string{},
+		},
+		{
+			name: "not action expands to every other action",
+			statements: []Statement{
+				{Effect: EffectAllow, NotAction: Value{"iam:*", "sts:*"}, Resource: Value{"*"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		doc := &PolicyDocument{Version: PolicyVersion, Statement: tt.statements}
+		got := doc.UnusedPermissions(activity)
+
+		if tt.unused != nil {
+			if !reflect.DeepEqual(got, tt.unused) {
+				t.Errorf("%s: got %v, want %v", tt.name, got, tt.unused)
+			}
+			continue
+		}
+
+		// NotAction is expanded like *, without the excluded actions and
+		// the called actions
+		want := make([]string, 0)
+		for _, a := range ExpandActions("*") {
+			if a.Service != "iam" && a.Service != "sts" && a.String() != "sqs:SendMessage" && a.String() != "s3:GetObject" {
+				want = append(want, a.String())
+			}
+		}
+		sort.Strings(want)
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %d actions, want %d", tt.name, len(got), len(want))
+		}
+	}
+}
@@ -0,0 +1,501 @@
+package sampolicies
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// ConditionConflictErr is returned when a condition is added to a policy that already has a condition on the same key
+	ConditionConflictErr = "policy %s already has a %s condition on %s"
+
+	// ConditionMergeErr is returned when two conditions on the same operator and key can't be merged into one that narrows both
+	ConditionMergeErr = "unable to merge the %s conditions on %s: %s"
+)
+
+// The condition operators of the IAM policy language. Every operator, except
+// Null, can get the IfExists suffix, and the ForAllValues: and ForAnyValue:
+// prefixes can be used with multivalued condition keys.
+const (
+	ConditionStringEquals              = "StringEquals"
+	ConditionStringNotEquals           = "StringNotEquals"
+	ConditionStringEqualsIgnoreCase    = "StringEqualsIgnoreCase"
+	ConditionStringNotEqualsIgnoreCase = "StringNotEqualsIgnoreCase"
+	ConditionStringLike                = "StringLike"
+	ConditionStringNotLike             = "StringNotLike"
+	ConditionNumericEquals             = "NumericEquals"
+	ConditionNumericNotEquals          = "NumericNotEquals"
+	ConditionNumericLessThan           = "NumericLessThan"
+	ConditionNumericLessThanEquals     = "NumericLessThanEquals"
+	ConditionNumericGreaterThan        = "NumericGreaterThan"
+	ConditionNumericGreaterThanEquals  = "NumericGreaterThanEquals"
+	ConditionDateEquals                = "DateEquals"
+	ConditionDateNotEquals             = "DateNotEquals"
+	ConditionDateLessThan              = "DateLessThan"
+	ConditionDateLessThanEquals        = "DateLessThanEquals"
+	ConditionDateGreaterThan           = "DateGreaterThan"
+	ConditionDateGreaterThanEquals     = "DateGreaterThanEquals"
+	ConditionBool                      = "Bool"
+	ConditionBinaryEquals              = "BinaryEquals"
+	ConditionIPAddress                 = "IpAddress"
+	ConditionNotIPAddress              = "NotIpAddress"
+	ConditionArnEquals                 = "ArnEquals"
+	ConditionArnNotEquals              = "ArnNotEquals"
+	ConditionArnLike                   = "ArnLike"
+	ConditionArnNotLike                = "ArnNotLike"
+	ConditionNull                      = "Null"
+)
+
+// conditionOperators are the condition operators without a prefix or suffix
+var conditionOperators = map[string]bool{
+	ConditionStringEquals: true, ConditionStringNotEquals: true, ConditionStringEqualsIgnoreCase: true,
+	ConditionStringNotEqualsIgnoreCase: true, ConditionStringLike: true, ConditionStringNotLike: true,
+	ConditionNumericEquals: true, ConditionNumericNotEquals: true, ConditionNumericLessThan: true,
+	ConditionNumericLessThanEquals: true, ConditionNumericGreaterThan: true, ConditionNumericGreaterThanEquals: true,
+	ConditionDateEquals: true, ConditionDateNotEquals: true, ConditionDateLessThan: true,
+	ConditionDateLessThanEquals: true, ConditionDateGreaterThan: true, ConditionDateGreaterThanEquals: true,
+	ConditionBool: true, ConditionBinaryEquals: true, ConditionIPAddress: true, ConditionNotIPAddress: true,
+	ConditionArnEquals: true, ConditionArnNotEquals: true, ConditionArnLike: true, ConditionArnNotLike: true,
+	ConditionNull: true,
+}
+
+// NewCondition returns a condition that compares the key against the values
+// with the operator. The values match if any of them matches.
+func NewCondition(operator string, key string, values ...string) Condition {
+	return Condition{operator: {key: Value(values)}}
+}
+
+// StringEquals returns a condition that matches if the key is exactly one of the values
+func StringEquals(key string, values ...string) Condition {
+	return NewCondition(ConditionStringEquals, key, values...)
+}
+
+// StringNotEquals returns a condition that matches if the key is none of the values
+func StringNotEquals(key string, values ...string) Condition {
+	return NewCondition(ConditionStringNotEquals, key, values...)
+}
+
+// StringLike returns a condition that matches if the key matches one of the
+// values, which can contain the * and ? wildcards
+func StringLike(key string, values ...string) Condition {
+	return NewCondition(ConditionStringLike, key, values...)
+}
+
+// StringNotLike returns a condition that matches if the key matches none of
+// the values, which can contain the * and ? wildcards
+func StringNotLike(key string, values ...string) Condition {
+	return NewCondition(ConditionStringNotLike, key, values...)
+}
+
+// ArnEquals returns a condition that matches if the key is one of the ARNs
+func ArnEquals(key string, arns ...string) Condition {
+	return NewCondition(ConditionArnEquals, key, arns...)
+}
+
+// ArnLike returns a condition that matches if the key matches one of the
+// ARNs, which can contain the * and ? wildcards
+func ArnLike(key string, arns ...string) Condition {
+	return NewCondition(ConditionArnLike, key, arns...)
+}
+
+// ArnNotLike returns a condition that matches if the key matches none of the
+// ARNs, which can contain the * and ? wildcards
+func ArnNotLike(key string, arns ...string) Condition {
+	return NewCondition(ConditionArnNotLike, key, arns...)
+}
+
+// IPAddress returns a condition that matches if the key is in one of the
+// IP address ranges, written in CIDR notation
+func IPAddress(key string, cidrs ...string) Condition {
+	return NewCondition(ConditionIPAddress, key, cidrs...)
+}
+
+// NotIPAddress returns a condition that matches if the key is in none of the
+// IP address ranges, written in CIDR notation
+func NotIPAddress(key string, cidrs ...string) Condition {
+	return NewCondition(ConditionNotIPAddress, key, cidrs...)
+}
+
+// Bool returns a condition that matches if the key has the boolean value
+func Bool(key string, value bool) Condition {
+	return NewCondition(ConditionBool, key, strconv.FormatBool(value))
+}
+
+// NumericLessThanEquals returns a condition that matches if the key is at most the value
+func NumericLessThanEquals(key string, value int) Condition {
+	return NewCondition(ConditionNumericLessThanEquals, key, strconv.Itoa(value))
+}
+
+// NumericGreaterThanEquals returns a condition that matches if the key is at least the value
+func NumericGreaterThanEquals(key string, value int) Condition {
+	return NewCondition(ConditionNumericGreaterThanEquals, key, strconv.Itoa(value))
+}
+
+// DateLessThan returns a condition that matches if the key is before the time
+func DateLessThan(key string, value time.Time) Condition {
+	return NewCondition(ConditionDateLessThan, key, value.UTC().Format(time.RFC3339))
+}
+
+// DateGreaterThan returns a condition that matches if the key is after the time
+func DateGreaterThan(key string, value time.Time) Condition {
+	return NewCondition(ConditionDateGreaterThan, key, value.UTC().Format(time.RFC3339))
+}
+
+// Null returns a condition that matches if the key is absent (true) or
+// present (false)
+func Null(key string, absent bool) Condition {
+	return NewCondition(ConditionNull, key, strconv.FormatBool(absent))
+}
+
+// Merge returns a new condition with the operators and keys of both
+// conditions, all of which must match. The merged condition only narrows
+// both conditions. When both conditions have the same operator and key,
+// IAM matches the key if any of the values matches, so the values are
+// merged depending on the operator:
+//
+//   - For equality operators, like StringEquals and ArnEquals, only the
+//     values in both conditions are kept.
+//   - For negated operators, like StringNotEquals, the values of both
+//     conditions are kept.
+//   - For numeric and date operators, like NumericLessThan, only the
+//     stricter bound is kept.
+//   - For IpAddress, only the ranges that lie within a range of the other
+//     condition are kept.
+//   - For patterns, like StringLike and ArnLike, only the patterns that are
+//     covered by a pattern of the other condition are kept.
+//
+// An error is returned if the merged condition can't match any request, or
+// if the values can't be narrowed into a single list, like two patterns
+// that overlap without one covering the other.
+func (c Condition) Merge(other Condition) (Condition, error) {
+	out := c.mapStrings(func(s string) string { return s })
+	if out == nil {
+		out = make(Condition, len(other))
+	}
+
+	for operator, keys := range other {
+		if out[operator] == nil {
+			out[operator] = make(map[string]Value, len(keys))
+		}
+		for key, val := range keys {
+			existing, ok := out[operator][key]
+			if !ok {
+				out[operator][key] = val.sorted()
+				continue
+			}
+
+			merged, err := mergeValues(operator, existing, val)
+			if err != nil {
+				return nil, fmt.Errorf(ConditionMergeErr, operator, key, err.Error())
+			}
+			out[operator][key] = merged
+		}
+	}
+	return out, nil
+}
+
+// mustMerge is like Merge but panics if an error is returned. It is used to
+// combine conditions on different keys, which always merge.
+func (c Condition) mustMerge(other Condition) Condition {
+	out, err := c.Merge(other)
+	if err != nil {
+		panic(err)
+	}
+	return out
+}
+
+// mergeValues returns the values of the same operator and key in two
+// conditions, narrowed to what both conditions allow
+func mergeValues(operator string, a Value, b Value) (Value, error) {
+	if isNegatedOperator(operator) {
+		return append(append(Value{}, a...), b...).sorted(), nil
+	}
+
+	var out Value
+	var err error
+	switch base := baseOperator(operator); base {
+	case ConditionNumericLessThan, ConditionNumericLessThanEquals, ConditionDateLessThan, ConditionDateLessThanEquals,
+		ConditionNumericGreaterThan, ConditionNumericGreaterThanEquals, ConditionDateGreaterThan, ConditionDateGreaterThanEquals:
+		return stricterBound(base, a, b)
+	case ConditionIPAddress:
+		out, err = intersectRanges(a, b)
+	case ConditionStringLike, ConditionArnLike:
+		out, err = intersectPatterns(a, b)
+	case ConditionStringEqualsIgnoreCase:
+		out = a.intersectFold(b)
+	default:
+		out = a.intersect(b)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	if len(out) == 0 {
+		return nil, fmt.Errorf("the values %s and %s have nothing in common", a, b)
+	}
+	return out, nil
+}
+
+// baseOperator returns the operator without the IfExists suffix and the set
+// operator prefixes
+func baseOperator(operator string) string {
+	for _, prefix := range []string{"ForAllValues:", "ForAnyValue:"} {
+		operator = strings.TrimPrefix(operator, prefix)
+	}
+	if operator != ConditionNull+"IfExists" {
+		operator = strings.TrimSuffix(operator, "IfExists")
+	}
+	return operator
+}
+
+// intersect returns the sorted values that are in both values
+func (v Value) intersect(other Value) Value {
+	out := make(Value, 0)
+	for _, val := range v {
+		if other.contains(val) {
+			out = append(out, val)
+		}
+	}
+	return out.sorted()
+}
+
+// intersectFold is like intersect, but compares the values case insensitively
+func (v Value) intersectFold(other Value) Value {
+	out := make(Value, 0)
+	for _, val := range v {
+		for _, o := range other {
+			if strings.EqualFold(val, o) {
+				out = append(out, val)
+				break
+			}
+		}
+	}
+	return out.sorted()
+}
+
+// stricterBound returns the stricter of the bounds of a numeric or date
+// operator. As any of the values can match, the bound of each condition is
+// its loosest value.
+func stricterBound(operator string, a Value, b Value) (Value, error) {
+	less := strings.Contains(operator, "LessThan")
+
+	bound := func(values Value) (string, float64, error) {
+		var loosest string
+		var limit float64
+		for idx, val := range values {
+			n, err := orderedValue(operator, val)
+			if err != nil {
+				return "", 0, err
+			}
+			if idx == 0 || (less && n > limit) || (!less && n < limit) {
+				loosest, limit = val, n
+			}
+		}
+		return loosest, limit, nil
+	}
+
+	valA, limitA, err := bound(a)
+	if err != nil {
+		return nil, err
+	}
+	valB, limitB, err := bound(b)
+	if err != nil {
+		return nil, err
+	}
+
+	if (less && limitB < limitA) || (!less && limitB > limitA) {
+		return Value{valB}, nil
+	}
+	return Value{valA}, nil
+}
+
+// orderedValue returns the number, or the date as seconds since the epoch,
+// of a value of a numeric or date operator
+func orderedValue(operator string, val string) (float64, error) {
+	if strings.HasPrefix(operator, "Date") {
+		t, err := parseDate(val)
+		if err != nil {
+			return 0, err
+		}
+		return float64(t.UnixNano()) / float64(time.Second), nil
+	}
+
+	n, err := strconv.ParseFloat(val, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", val)
+	}
+	return n, nil
+}
+
+// intersectRanges returns the IP address ranges of both values that lie
+// within a range of the other value
+func intersectRanges(a Value, b Value) (Value, error) {
+	parse := func(values Value) ([]*net.IPNet, error) {
+		out := make([]*net.IPNet, len(values))
+		for idx, val := range values {
+			cidr := val
+			if !strings.Contains(cidr, "/") {
+				if ip := net.ParseIP(cidr); ip != nil && ip.To4() != nil {
+					cidr += "/32"
+				} else {
+					cidr += "/128"
+				}
+			}
+			_, network, err := net.ParseCIDR(cidr)
+			if err != nil {
+				return nil, fmt.Errorf("%q is not an IP address range", val)
+			}
+			out[idx] = network
+		}
+		return out, nil
+	}
+
+	netsA, err := parse(a)
+	if err != nil {
+		return nil, err
+	}
+	netsB, err := parse(b)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(Value, 0)
+	for i, x := range netsA {
+		for j, y := range netsB {
+			onesX, _ := x.Mask.Size()
+			onesY, _ := y.Mask.Size()
+			switch {
+			case onesX >= onesY && y.Contains(x.IP):
+				out = append(out, a[i])
+			case onesY > onesX && x.Contains(y.IP):
+				out = append(out, b[j])
+			}
+		}
+	}
+	return out.sorted(), nil
+}
+
+// intersectPatterns returns the patterns of both values that are covered by
+// a pattern of the other value. An error is returned if two patterns might
+// match the same value without one covering the other, as the list can't
+// express what both patterns match.
+func intersectPatterns(a Value, b Value) (Value, error) {
+	out := make(Value, 0)
+	for _, x := range a {
+		for _, y := range b {
+			switch {
+			case wildcardRegexp(y, false).MatchString(x):
+				out = append(out, x)
+			case wildcardRegexp(x, false).MatchString(y):
+				out = append(out, y)
+			case patternsOverlap(x, y):
+				return nil, fmt.Errorf("the patterns %q and %q overlap without one covering the other", x, y)
+			}
+		}
+	}
+	return out.sorted(), nil
+}
+
+// patternsOverlap returns false if the patterns can't match the same value,
+// because their literal prefixes or suffixes differ
+func patternsOverlap(a string, b string) bool {
+	literal := func(s string, fromEnd bool) string {
+		if fromEnd {
+			return s[strings.LastIndexAny(s, "*?")+1:]
+		}
+		if idx := strings.IndexAny(s, "*?"); idx >= 0 {
+			return s[:idx]
+		}
+		return s
+	}
+
+	prefixA, prefixB := literal(a, false), literal(b, false)
+	if !strings.HasPrefix(prefixA, prefixB) && !strings.HasPrefix(prefixB, prefixA) {
+		return false
+	}
+	suffixA, suffixB := literal(a, true), literal(b, true)
+	return strings.HasSuffix(suffixA, suffixB) || strings.HasSuffix(suffixB, suffixA)
+}
+
+// WithCondition adds the condition to every Allow statement of the policies
+// in the factory, including those added later, and returns a pointer to the
+// existing resource to allow chaining. Calling it again merges the
+// conditions like Merge, so a second call can only narrow the grants. The
+// condition can use the same placeholders as the statements. Conditions
+// that can't be merged, and a policy that already has a condition on one of
+// the same keys, result in an error when the policy document is created.
+func (f *Factory) WithCondition(condition Condition) *Factory {
+	merged, err := f.condition.Merge(condition)
+	if err != nil {
+		f.conditionErr = err
+		return f
+	}
+	f.condition = merged
+	return f
+}
+
+// AddWithCondition is like Add, but adds the condition to every Allow
+// statement of the template. This allows you to restrict a grant, for
+// example to requests through a VPC endpoint. An error is returned if the
+// template already has a condition on one of the same keys.
+func (f *Factory) AddWithCondition(name string, params map[string]string, condition Condition) error {
+	t, ok := f.template(name)
+	if !ok {
+		return fmt.Errorf(UnknownTemplateErr, name)
+	}
+
+	names := make([]string, 0, len(params))
+	for key := range params {
+		names = append(names, key)
+	}
+	if err := t.checkParameters(names); err != nil {
+		return err
+	}
+
+	statements, err := addCondition(t.Name, t.Statements, condition)
+	if err != nil {
+		return err
+	}
+
+	f.addPolicy(t.Name, params, statements...)
+	return nil
+}
+
+// addCondition returns copies of the statements of a policy with the
+// condition added to the Allow statements. Deny statements are left as they
+// are, as a condition would narrow down what they deny.
+func addCondition(name string, statements []Statement, condition Condition) ([]Statement, error) {
+	out := make([]Statement, len(statements))
+	for idx := range statements {
+		out[idx] = statements[idx].copy()
+		if len(condition) == 0 || out[idx].Effect != EffectAllow {
+			continue
+		}
+
+		for operator, keys := range condition {
+			for key := range keys {
+				if _, ok := out[idx].Condition[operator][key]; ok {
+					return nil, fmt.Errorf(ConditionConflictErr, name, operator, key)
+				}
+			}
+		}
+		out[idx].Condition = out[idx].Condition.mustMerge(condition)
+	}
+	return out, nil
+}
+
+// isNegatedOperator returns true if the operator matches when the key
+// matches none of the values, like StringNotEquals and NotIpAddress
+func isNegatedOperator(operator string) bool {
+	_, ok := negatedOperators[baseOperator(operator)]
+	return ok
+}
+
+// isConditionOperator returns true if the operator is a condition operator,
+// with or without the IfExists suffix and a set operator prefix.
+func isConditionOperator(operator string) bool {
+	return conditionOperators[baseOperator(operator)]
+}
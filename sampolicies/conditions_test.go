@@ -0,0 +1,184 @@
+package sampolicies
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConditionMerge(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  Condition
+		merge Condition
+		err   bool
+	}{
+		{
+			name:  "different keys",
+			a:     StringEquals("aws:SourceVpce", "vpce-1"),
+			b:     Bool("aws:SecureTransport", true),
+			merge: Condition{ConditionStringEquals: {"aws:SourceVpce": {"vpce-1"}}, ConditionBool: {"aws:SecureTransport": {"true"}}},
+		},
+		{
+			name:  "equality keeps common values",
+			a:     StringEquals("aws:PrincipalTag/team", "payments", "billing"),
+			b:     StringEquals("aws:PrincipalTag/team", "billing", "search"),
+			merge: Condition{ConditionStringEquals: {"aws:PrincipalTag/team": {"billing"}}},
+		},
+		{
+			name:  "equality ignoring case keeps common values",
+			a:     NewCondition(ConditionStringEqualsIgnoreCase, "aws:PrincipalTag/team", "Payments"),
+			b:     NewCondition(ConditionStringEqualsIgnoreCase, "aws:PrincipalTag/team", "payments"),
+			merge: Condition{ConditionStringEqualsIgnoreCase: {"aws:PrincipalTag/team": {"Payments"}}},
+		},
+		{
+			name: "equality without common values",
+			a:    StringEquals("aws:PrincipalTag/team", "payments"),
+			b:    StringEquals("aws:PrincipalTag/team", "search"),
+			err:  true,
+		},
+		{
+			name: "arn equality without common values",
+			a:    ArnEquals("aws:SourceArn", "arn:aws:sns:us-east-1:123456789012:a"),
+			b:    ArnEquals("aws:SourceArn", "arn:aws:sns:us-east-1:123456789012:b"),
+			err:  true,
+		},
+		{
+			name:  "negated operator excludes all values",
+			a:     StringNotEquals("aws:PrincipalTag/team", "payments"),
+			b:     StringNotEquals("aws:PrincipalTag/team", "search"),
+			merge: Condition{ConditionStringNotEquals: {"aws:PrincipalTag/team": {"payments", "search"}}},
+		},
+		{
+			name:  "numeric less than keeps the lower bound",
+			a:     NewCondition(ConditionNumericLessThan, "s3:max-keys", "10"),
+			b:     NewCondition(ConditionNumericLessThan, "s3:max-keys", "5"),
+			merge: Condition{ConditionNumericLessThan: {"s3:max-keys": {"5"}}},
+		},
+		{
+			name:  "numeric less than uses the loosest value of each condition",
+			a:     NewCondition(ConditionNumericLessThan, "s3:max-keys", "3", "20"),
+			b:     NewCondition(ConditionNumericLessThan, "s3:max-keys", "10"),
+			merge: Condition{ConditionNumericLessThan: {"s3:max-keys": {"10"}}},
+		},
+		{
+			name:  "numeric greater than keeps the higher bound",
+			a:     NumericGreaterThanEquals("s3:max-keys", 1),
+			b:     NumericGreaterThanEquals("s3:max-keys", 4),
+			merge: Condition{ConditionNumericGreaterThanEquals: {"s3:max-keys": {"4"}}},
+		},
+		{
+			name:  "date less than keeps the earlier date",
+			a:     NewCondition(ConditionDateLessThan, "aws:CurrentTime", "2030-01-01T00:00:00Z"),
+			b:     NewCondition(ConditionDateLessThan, "aws:CurrentTime", "2029-06-01T00:00:00Z"),
+			merge: Condition{ConditionDateLessThan: {"aws:CurrentTime": {"2029-06-01T00:00:00Z"}}},
+		},
+		{
+			name:  "date greater than with the IfExists suffix keeps the later date",
+			a:     NewCondition(ConditionDateGreaterThan+"IfExists", "aws:CurrentTime", "2029-01-01"),
+			b:     NewCondition(ConditionDateGreaterThan+"IfExists", "aws:CurrentTime", "2028-01-01"),
+			merge: Condition{ConditionDateGreaterThan + "IfExists": {"aws:CurrentTime": {"2029-01-01"}}},
+		},
+		{
+			name: "numeric bound that isn't a number",
+			a:    NewCondition(ConditionNumericLessThan, "s3:max-keys", "${MaxKeys}"),
+			b:    NewCondition(ConditionNumericLessThan, "s3:max-keys", "5"),
+			err:  true,
+		},
+		{
+			name:  "ip address keeps the narrower range",
+			a:     IPAddress("aws:SourceIp", "10.0.0.0/8"),
+			b:     IPAddress("aws:SourceIp", "10.1.0.0/16"),
+			merge: Condition{ConditionIPAddress: {"aws:SourceIp": {"10.1.0.0/16"}}},
+		},
+		{
+			name:  "ip address keeps the ranges within the other condition",
+			a:     IPAddress("aws:SourceIp", "10.1.0.0/16", "192.168.1.10", "172.16.0.0/12"),
+			b:     IPAddress("aws:SourceIp", "10.0.0.0/8", "192.168.0.0/16"),
+			merge: Condition{ConditionIPAddress: {"aws:SourceIp": {"10.1.0.0/16", "192.168.1.10"}}},
+		},
+		{
+			name: "ip address with disjoint ranges",
+			a:    IPAddress("aws:SourceIp", "10.0.0.0/8"),
+			b:    IPAddress("aws:SourceIp", "192.168.0.0/16"),
+			err:  true,
+		},
+		{
+			name:  "string like keeps the covered pattern",
+			a:     StringLike("s3:prefix", "home/*"),
+			b:     StringLike("s3:prefix", "home/alice/*"),
+			merge: Condition{ConditionStringLike: {"s3:prefix": {"home/alice/*"}}},
+		},
+		{
+			name:  "arn like skips disjoint patterns",
+			a:     ArnLike("aws:SourceArn", "arn:aws:sns:*:*:orders-*", "arn:aws:sqs:*:*:*"),
+			b:     ArnLike("aws:SourceArn", "arn:aws:sns:us-east-1:*:orders-eu"),
+			merge: Condition{ConditionArnLike: {"aws:SourceArn": {"arn:aws:sns:us-east-1:*:orders-eu"}}},
+		},
+		{
+			name: "string like with overlapping patterns",
+			a:    StringLike("s3:prefix", "home/*"),
+			b:    StringLike("s3:prefix", "*/public"),
+			err:  true,
+		},
+		{
+			name: "arn like with disjoint patterns",
+			a:    ArnLike("aws:SourceArn", "arn:aws:sns:*"),
+			b:    ArnLike("aws:SourceArn", "arn:aws:sqs:*"),
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.a.Merge(tt.b)
+		if tt.err {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %v", tt.name, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tt.merge) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.merge)
+		}
+	}
+}
+
+func TestWithConditionDoesNotWiden(t *testing.T) {
+	f := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1").
+		WithCondition(StringEquals("aws:PrincipalTag/team", "payments", "billing")).
+		WithCondition(StringEquals("aws:PrincipalTag/team", "billing", "search"))
+	f.AddSQSPollerPolicy("jobs")
+
+	tests := []struct {
+		team     string
+		decision Decision
+	}{
+		{"billing", DecisionAllow},
+		{"payments", DecisionImplicitDeny},
+		{"search", DecisionImplicitDeny},
+	}
+
+	for _, tt := range tests {
+		e, err := f.Evaluate("sqs:ReceiveMessage", "arn:aws:sqs:us-east-1:123456789012:jobs", map[string]Value{"aws:PrincipalTag/team": {tt.team}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if e.Decision != tt.decision {
+			t.Errorf("team %s: got %s, want %s", tt.team, e.Decision, tt.decision)
+		}
+	}
+}
+
+func TestWithConditionWithoutCommonValues(t *testing.T) {
+	f := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1").
+		WithCondition(StringEquals("aws:PrincipalTag/team", "payments")).
+		WithCondition(StringEquals("aws:PrincipalTag/team", "search"))
+	f.AddSQSPollerPolicy("jobs")
+
+	if _, err := f.GetPolicyDocument(); err == nil {
+		t.Error("expected an error for conditions without common values")
+	}
+}
@@ -0,0 +1,68 @@
+package sampolicies
+
+import (
+	"strings"
+
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+// regionPartitions maps the prefixes of region names to their partition
+var regionPartitions = []struct {
+	prefix    string
+	partition string
+}{
+	{prefix: "cn-", partition: "aws-cn"},
+	{prefix: "us-gov-", partition: "aws-us-gov"},
+	{prefix: "us-isob-", partition: "aws-iso-b"},
+	{prefix: "us-iso-", partition: "aws-iso"},
+}
+
+// NewFactoryFromContext returns a new Factory with the accountID, partition,
+// and region of the AWS provider of the Pulumi program, so they don't have
+// to be hardcoded. The values are looked up with aws.GetCallerIdentity,
+// aws.GetPartition, and aws.GetRegion, and an error is returned if any of
+// them fails.
+func NewFactoryFromContext(ctx *pulumi.Context) (*Factory, error) {
+	identity, err := aws.GetCallerIdentity(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	partition, err := aws.GetPartition(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	region, err := aws.GetRegion(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewFactory().WithAccountID(identity.AccountId).WithPartition(partition.Partition).WithRegion(region.Name), nil
+}
+
+// PartitionForRegion returns the AWS partition of the region, like aws-cn for
+// cn-north-1 and aws-us-gov for us-gov-west-1. All other regions are in the
+// aws partition. An empty region has no partition.
+func PartitionForRegion(region string) string {
+	if len(region) == 0 {
+		return ""
+	}
+
+	for _, p := range regionPartitions {
+		if strings.HasPrefix(region, p.prefix) {
+			return p.partition
+		}
+	}
+	return "aws"
+}
+
+// Partition returns the AWS partition of the factory. If no partition is
+// set, it's inferred from the region.
+func (f *Factory) Partition() string {
+	if len(f.partition) == 0 {
+		return PartitionForRegion(f.region)
+	}
+	return f.partition
+}
@@ -0,0 +1,37 @@
+package sampolicies
+
+import "testing"
+
+func TestPartitionForRegion(t *testing.T) {
+	tests := []struct {
+		region    string
+		partition string
+	}{
+		{"", ""},
+		{"us-east-1", "aws"},
+		{"eu-west-1", "aws"},
+		{"ap-southeast-2", "aws"},
+		{"me-south-1", "aws"},
+		{"cn-north-1", "aws-cn"},
+		{"cn-northwest-1", "aws-cn"},
+		{"us-gov-west-1", "aws-us-gov"},
+		{"us-gov-east-1", "aws-us-gov"},
+		{"us-iso-east-1", "aws-iso"},
+		{"us-isob-east-1", "aws-iso-b"},
+	}
+
+	for _, tt := range tests {
+		if got := PartitionForRegion(tt.region); got != tt.partition {
+			t.Errorf("%q: got %q, want %q", tt.region, got, tt.partition)
+		}
+	}
+}
+
+func TestFactoryPartition(t *testing.T) {
+	if got := NewFactory().WithRegion("cn-north-1").Partition(); got != "aws-cn" {
+		t.Errorf("got %q for an inferred partition, want aws-cn", got)
+	}
+	if got := NewFactory().WithRegion("cn-north-1").WithPartition("aws").Partition(); got != "aws" {
+		t.Errorf("got %q for a set partition, want aws", got)
+	}
+}
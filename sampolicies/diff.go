@@ -0,0 +1,398 @@
+package sampolicies
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ChangeKind is the kind of change between two policy documents.
+type ChangeKind string
+
+const (
+	// ActionsAdded means the new policy grants or denies actions on a resource
+	// that the old policy didn't
+	ActionsAdded ChangeKind = "added"
+
+	// ActionsRemoved means the new policy no longer grants or denies actions
+	// on a resource that the old policy did
+	ActionsRemoved ChangeKind = "removed"
+
+	// ResourceWidened means the new policy has the actions on a resource
+	// pattern that covers the resource of the old policy
+	ResourceWidened ChangeKind = "widened"
+
+	// ResourceNarrowed means the new policy has the actions on a resource
+	// that is covered by the resource pattern of the old policy
+	ResourceNarrowed ChangeKind = "narrowed"
+
+	// ConditionChanged means the new policy has the actions on the same
+	// resource, but with different conditions
+	ConditionChanged ChangeKind = "condition changed"
+)
+
+// Change is a single semantic change between two policy documents.
+type Change struct {
+	Kind ChangeKind
+
+	// Effect is the effect of the statements that changed
+	Effect string
+
+	// Principal is the JSON of the Principal or NotPrincipal of the
+	// statements, which is empty for identity policies
+	Principal string
+
+	// Resource is the resource of the new policy, or of the old policy if the
+	// actions were removed. It is empty for statements without a resource,
+	// like those of a trust policy.
+	Resource string
+
+	// OldResource is the resource of the old policy when the resource was
+	// widened or narrowed
+	OldResource string
+
+	// Actions are the actions that changed
+	Actions []string
+
+	// OldCondition and NewCondition are the JSON of the conditions when they
+	// changed. Multiple conditions that each grant the actions are separated
+	// by " OR ".
+	OldCondition string
+	NewCondition string
+}
+
+// String returns the change as a single line, prefixed with + for added
+// permissions, - for removed permissions, and ~ for modified permissions.
+func (c Change) String() string {
+	var b strings.Builder
+
+	switch c.Kind {
+	case ActionsAdded:
+		b.WriteString("+ ")
+	case ActionsRemoved:
+		b.WriteString("- ")
+	default:
+		b.WriteString("~ ")
+	}
+
+	b.WriteString(fmt.Sprintf("%s %s", c.Effect, strings.Join(c.Actions, ", ")))
+	if len(c.Principal) > 0 {
+		b.WriteString(fmt.Sprintf(" for %s", c.Principal))
+	}
+
+	switch c.Kind {
+	case ResourceWidened, ResourceNarrowed:
+		b.WriteString(fmt.Sprintf(" %s from %s to %s", c.Kind, c.OldResource, c.Resource))
+	case ConditionChanged:
+		if len(c.Resource) > 0 {
+			b.WriteString(fmt.Sprintf(" on %s", c.Resource))
+		}
+		b.WriteString(fmt.Sprintf(": condition changed from %s to %s", c.OldCondition, c.NewCondition))
+	default:
+		if len(c.Resource) > 0 {
+			b.WriteString(fmt.Sprintf(" on %s", c.Resource))
+		}
+	}
+	return b.String()
+}
+
+// PolicyDiff is the list of semantic changes between two policy documents.
+type PolicyDiff struct {
+	Changes []Change
+}
+
+// Empty returns true if both policy documents grant and deny the same
+// permissions
+func (d PolicyDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// String returns the changes with one change per line
+func (d PolicyDiff) String() string {
+	lines := make([]string, len(d.Changes))
+	for idx, c := range d.Changes {
+		lines[idx] = c.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// permissions maps the effect and principal of a statement to the resources,
+// the actions on each resource, and the conditions of each action
+type permissions map[permissionScope]map[string]map[string]*permission
+
+// permissionScope is the effect and principal a permission applies to
+type permissionScope struct {
+	effect    string
+	principal string
+}
+
+// permission is a single action on a resource
+type permission struct {
+	action     string
+	conditions map[string]bool
+}
+
+// DiffPolicyDocuments compares the permissions of two policy documents,
+// instead of their JSON. The order of statements and values, the way
+// permissions are split over statements, and the case of actions don't
+// matter.
+func DiffPolicyDocuments(oldDoc *PolicyDocument, newDoc *PolicyDocument) PolicyDiff {
+	oldPerms := flattenPermissions(oldDoc)
+	newPerms := flattenPermissions(newDoc)
+
+	scopes := make([]permissionScope, 0)
+	seen := make(map[permissionScope]bool)
+	for _, perms := range []permissions{oldPerms, newPerms} {
+		for scope := range perms {
+			if !seen[scope] {
+				seen[scope] = true
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	sort.Slice(scopes, func(i, j int) bool {
+		if scopes[i].effect != scopes[j].effect {
+			return scopes[i].effect < scopes[j].effect
+		}
+		return scopes[i].principal < scopes[j].principal
+	})
+
+	changes := make([]Change, 0)
+	for _, scope := range scopes {
+		changes = append(changes, diffScope(scope, oldPerms[scope], newPerms[scope])...)
+	}
+	return PolicyDiff{Changes: changes}
+}
+
+// DiffPolicies parses both policy documents and compares them like
+// DiffPolicyDocuments.
+func DiffPolicies(oldPolicy string, newPolicy string) (PolicyDiff, error) {
+	oldDoc, err := ParsePolicyDocument(oldPolicy)
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+
+	newDoc, err := ParsePolicyDocument(newPolicy)
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+
+	return DiffPolicyDocuments(oldDoc, newDoc), nil
+}
+
+// Diff creates the policy documents of both factories and compares them like
+// DiffPolicyDocuments.
+func (f *Factory) Diff(other *Factory) (PolicyDiff, error) {
+	oldDoc, err := f.GetPolicyDocument()
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+
+	newDoc, err := other.GetPolicyDocument()
+	if err != nil {
+		return PolicyDiff{}, err
+	}
+
+	return DiffPolicyDocuments(oldDoc, newDoc), nil
+}
+
+// flattenPermissions splits the statements of the document into single
+// actions on single resources. NotAction and NotResource are kept as a
+// single action or resource.
+func flattenPermissions(doc *PolicyDocument) permissions {
+	perms := make(permissions)
+
+	for _, s := range doc.Statement {
+		s = s.canonical()
+
+		scope := permissionScope{effect: s.Effect}
+		switch {
+		case len(s.Principal) > 0:
+			b, _ := json.Marshal(s.Principal)
+			scope.principal = string(b)
+		case len(s.NotPrincipal) > 0:
+			b, _ := json.Marshal(s.NotPrincipal)
+			scope.principal = fmt.Sprintf("everyone except %s", string(b))
+		}
+
+		resources := s.Resource
+		switch {
+		case len(s.NotResource) > 0:
+			resources = Value{fmt.Sprintf("all resources except %s", strings.Join(s.NotResource, ", "))}
+		case len(resources) == 0:
+			resources = Value{""}
+		}
+
+		actions := s.Action
+		if len(s.NotAction) > 0 {
+			actions = Value{fmt.Sprintf("all actions except %s", strings.Join(s.NotAction, ", "))}
+		}
+
+		condition := "none"
+		if len(s.Condition) > 0 {
+			b, _ := json.Marshal(s.Condition)
+			condition = string(b)
+		}
+
+		if perms[scope] == nil {
+			perms[scope] = make(map[string]map[string]*permission)
+		}
+		for _, resource := range resources {
+			if perms[scope][resource] == nil {
+				perms[scope][resource] = make(map[string]*permission)
+			}
+			for _, action := range actions {
+				key := strings.ToLower(action)
+				if perms[scope][resource][key] == nil {
+					perms[scope][resource][key] = &permission{action: action, conditions: make(map[string]bool)}
+				}
+				perms[scope][resource][key].conditions[condition] = true
+			}
+		}
+	}
+	return perms
+}
+
+// diffScope compares the permissions of a single effect and principal
+func diffScope(scope permissionScope, oldPerms map[string]map[string]*permission, newPerms map[string]map[string]*permission) []Change {
+	added := make(map[string]map[string]string)
+	removed := make(map[string]map[string]string)
+	conditions := make(map[[3]string][]string)
+
+	resourceSet := make(map[string]bool)
+	for _, perms := range []map[string]map[string]*permission{oldPerms, newPerms} {
+		for resource := range perms {
+			resourceSet[resource] = true
+		}
+	}
+	resources := sortedKeys(resourceSet)
+
+	for _, resource := range resources {
+		keySet := make(map[string]bool)
+		for _, perms := range []map[string]*permission{oldPerms[resource], newPerms[resource]} {
+			for key := range perms {
+				keySet[key] = true
+			}
+		}
+
+		for _, key := range sortedKeys(keySet) {
+			oldPerm, inOld := oldPerms[resource][key]
+			newPerm, inNew := newPerms[resource][key]
+
+			switch {
+			case inOld && inNew:
+				oldCondition, newCondition := oldPerm.condition(), newPerm.condition()
+				if oldCondition != newCondition {
+					id := [3]string{resource, oldCondition, newCondition}
+					conditions[id] = append(conditions[id], newPerm.action)
+				}
+			case inNew:
+				if added[resource] == nil {
+					added[resource] = make(map[string]string)
+				}
+				added[resource][key] = newPerm.action
+			case inOld:
+				if removed[resource] == nil {
+					removed[resource] = make(map[string]string)
+				}
+				removed[resource][key] = oldPerm.action
+			}
+		}
+	}
+
+	changes := make([]Change, 0)
+	change := func(kind ChangeKind, resource string, actions []string) Change {
+		sort.Strings(actions)
+		return Change{Kind: kind, Effect: scope.effect, Principal: scope.principal, Resource: resource, Actions: actions}
+	}
+
+	// Actions that moved to a resource pattern that covers, or is covered
+	// by, the old resource are reported as a widened or narrowed resource
+	for _, newResource := range resources {
+		if len(added[newResource]) == 0 {
+			continue
+		}
+		for _, oldResource := range resources {
+			if len(removed[oldResource]) == 0 {
+				continue
+			}
+
+			kind := ChangeKind("")
+			switch {
+			case wildcardRegexp(newResource, false).MatchString(oldResource):
+				kind = ResourceWidened
+			case wildcardRegexp(oldResource, false).MatchString(newResource):
+				kind = ResourceNarrowed
+			default:
+				continue
+			}
+
+			actions := make([]string, 0)
+			for key := range added[newResource] {
+				if _, ok := removed[oldResource][key]; ok {
+					actions = append(actions, added[newResource][key])
+					delete(added[newResource], key)
+					delete(removed[oldResource], key)
+				}
+			}
+			if len(actions) > 0 {
+				c := change(kind, newResource, actions)
+				c.OldResource = oldResource
+				changes = append(changes, c)
+			}
+		}
+	}
+
+	for _, resource := range resources {
+		if actions := sortedValues(added[resource]); len(actions) > 0 {
+			changes = append(changes, change(ActionsAdded, resource, actions))
+		}
+	}
+	for _, resource := range resources {
+		if actions := sortedValues(removed[resource]); len(actions) > 0 {
+			changes = append(changes, change(ActionsRemoved, resource, actions))
+		}
+	}
+
+	ids := make([][3]string, 0, len(conditions))
+	for id := range conditions {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return strings.Join(ids[i][:], "\x00") < strings.Join(ids[j][:], "\x00")
+	})
+	for _, id := range ids {
+		c := change(ConditionChanged, id[0], conditions[id])
+		c.OldCondition, c.NewCondition = id[1], id[2]
+		changes = append(changes, c)
+	}
+
+	return changes
+}
+
+// condition returns the conditions of the permission, sorted and separated
+// by " OR "
+func (p *permission) condition() string {
+	return strings.Join(sortedKeys(p.conditions), " OR ")
+}
+
+// sortedKeys returns the sorted members of the set
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sortedValues returns the sorted values of the map
+func sortedValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, val := range m {
+		values = append(values, val)
+	}
+	sort.Strings(values)
+	return values
+}
@@ -0,0 +1,102 @@
+package sampolicies
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiffPolicies(t *testing.T) {
+	const table = "arn:aws:dynamodb:us-east-1:123456789012:table/orders"
+
+	tests := []struct {
+		name    string
+		old     string
+		new     string
+		changes []string
+	}{
+		{
+			name:    "same permissions in a different shape",
+			old:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["dynamodb:GetItem","dynamodb:Query"],"Resource":"` + table + `"}]}`,
+			new:     `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"DYNAMODB:query","Resource":"` + table + `"},{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":["` + table + `"]}]}`,
+			changes: []string{},
+		},
+		{
+			name: "added actions",
+			old:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":"` + table + `"}]}`,
+			new:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["dynamodb:GetItem","dynamodb:PutItem","dynamodb:DeleteItem"],"Resource":"` + table + `"}]}`,
+			changes: []string{
+				"+ Allow dynamodb:DeleteItem, dynamodb:PutItem on " + table,
+			},
+		},
+		{
+			name: "removed actions",
+			old:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["dynamodb:GetItem","dynamodb:Query"],"Resource":"` + table + `"}]}`,
+			new:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":"` + table + `"}]}`,
+			changes: []string{
+				"- Allow dynamodb:Query on " + table,
+			},
+		},
+		{
+			name: "widened resource",
+			old:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":"` + table + `"}]}`,
+			new:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":"arn:aws:dynamodb:us-east-1:123456789012:table/*"}]}`,
+			changes: []string{
+				"~ Allow dynamodb:GetItem widened from " + table + " to arn:aws:dynamodb:us-east-1:123456789012:table/*",
+			},
+		},
+		{
+			name: "narrowed resource",
+			old:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::uploads/*"}]}`,
+			new:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::uploads/public/*"}]}`,
+			changes: []string{
+				"~ Allow s3:GetObject narrowed from arn:aws:s3:::uploads/* to arn:aws:s3:::uploads/public/*",
+			},
+		},
+		{
+			name: "changed condition",
+			old:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":"` + table + `"}]}`,
+			new:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":"` + table + `","Condition":{"StringEquals":{"aws:SourceVpce":"vpce-1"}}}]}`,
+			changes: []string{
+				`~ Allow dynamodb:GetItem on ` + table + `: condition changed from none to {"StringEquals":{"aws:SourceVpce":"vpce-1"}}`,
+			},
+		},
+		{
+			name: "added deny",
+			old:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":"` + table + `"}]}`,
+			new:  `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":"` + table + `"},{"Effect":"Deny","Action":"dynamodb:DeleteTable","Resource":"*"}]}`,
+			changes: []string{
+				"+ Deny dynamodb:DeleteTable on *",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		diff, err := DiffPolicies(tt.old, tt.new)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+
+		got := make([]string, len(diff.Changes))
+		for idx, c := range diff.Changes {
+			got[idx] = c.String()
+		}
+		if !reflect.DeepEqual(got, tt.changes) {
+			t.Errorf("%s: got\n%s\nwant\n%s", tt.name, strings.Join(got, "\n"), strings.Join(tt.changes, "\n"))
+		}
+		if diff.Empty() != (len(tt.changes) == 0) {
+			t.Errorf("%s: got Empty() %v", tt.name, diff.Empty())
+		}
+	}
+}
+
+func TestDiffPoliciesInvalidDocument(t *testing.T) {
+	valid := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`
+	if _, err := DiffPolicies(valid, `{"Statement":"nope"}`); err == nil {
+		t.Error("expected an error for an invalid new policy")
+	}
+	if _, err := DiffPolicies(`not json`, valid); err == nil {
+		t.Error("expected an error for an invalid old policy")
+	}
+}
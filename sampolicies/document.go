@@ -0,0 +1,238 @@
+package sampolicies
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	// PolicyVersion is the version of the IAM policy language used by all documents
+	PolicyVersion = "2012-10-17"
+
+	// EffectAllow allows access to the actions and resources in a statement
+	EffectAllow = "Allow"
+
+	// EffectDeny explicitly denies access to the actions and resources in a statement
+	EffectDeny = "Deny"
+)
+
+// PolicyDocument is the typed representation of an AWS IAM policy document.
+type PolicyDocument struct {
+	Version   string      `json:"Version"`
+	ID        string      `json:"Id,omitempty"`
+	Statement []Statement `json:"Statement"`
+}
+
+// Statement is a single statement of an AWS IAM policy document. The
+// Action, Resource, and Principal elements accept either a single string
+// or an array of strings in JSON, but are always a list in Go.
+type Statement struct {
+	Sid          string    `json:"Sid,omitempty"`
+	Effect       string    `json:"Effect"`
+	Principal    Principal `json:"Principal,omitempty"`
+	NotPrincipal Principal `json:"NotPrincipal,omitempty"`
+	Action       Value     `json:"Action,omitempty"`
+	NotAction    Value     `json:"NotAction,omitempty"`
+	Resource     Value     `json:"Resource,omitempty"`
+	NotResource  Value     `json:"NotResource,omitempty"`
+	Condition    Condition `json:"Condition,omitempty"`
+}
+
+// Value is a list of strings in a policy statement. A Value with a single
+// element is marshalled as a plain string.
+type Value []string
+
+// Principal maps a principal type (like AWS, Service, or Federated) to the
+// principals of that type. The anonymous principal "*" is represented as
+// {"AWS": ["*"]}.
+type Principal map[string]Value
+
+// Condition maps a condition operator (like StringEquals) to the condition
+// keys and the values they're compared against.
+type Condition map[string]map[string]Value
+
+// MarshalJSON marshals a Value with one element as a string and all other
+// values as an array.
+func (v Value) MarshalJSON() ([]byte, error) {
+	if len(v) == 1 {
+		return marshalJSON(v[0])
+	}
+	return marshalJSON([]string(v))
+}
+
+// UnmarshalJSON accepts both a single value and an array of values. Booleans
+// and numbers, which can be used in conditions, are converted to strings.
+func (v *Value) UnmarshalJSON(data []byte) error {
+	var a []json.RawMessage
+	if err := json.Unmarshal(data, &a); err != nil {
+		a = []json.RawMessage{data}
+	}
+
+	out := make(Value, len(a))
+	for idx := range a {
+		var scalar interface{}
+		if err := json.Unmarshal(a[idx], &scalar); err != nil {
+			return err
+		}
+
+		switch val := scalar.(type) {
+		case string:
+			out[idx] = val
+		case bool, float64:
+			out[idx] = string(a[idx])
+		default:
+			return fmt.Errorf("%s is not a string, boolean, or number", string(a[idx]))
+		}
+	}
+	*v = out
+	return nil
+}
+
+// MarshalJSON marshals the anonymous principal as "*" and all other
+// principals as an object.
+func (p Principal) MarshalJSON() ([]byte, error) {
+	if p.isAnonymous() {
+		return marshalJSON("*")
+	}
+	return marshalJSON(map[string]Value(p))
+}
+
+// UnmarshalJSON accepts both "*" and an object of principals.
+func (p *Principal) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*p = Principal{"AWS": Value{s}}
+		return nil
+	}
+
+	var m map[string]Value
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*p = Principal(m)
+	return nil
+}
+
+// isAnonymous returns true if the principal is "*"
+func (p Principal) isAnonymous() bool {
+	return len(p) == 1 && len(p["AWS"]) == 1 && p["AWS"][0] == "*"
+}
+
+// UnmarshalJSON accepts a Statement element with both a single statement and
+// an array of statements.
+func (d *PolicyDocument) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Version   string          `json:"Version"`
+		ID        string          `json:"Id"`
+		Statement json.RawMessage `json:"Statement"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	var statements []Statement
+	if err := json.Unmarshal(raw.Statement, &statements); err != nil {
+		var statement Statement
+		if err := json.Unmarshal(raw.Statement, &statement); err != nil {
+			return err
+		}
+		statements = []Statement{statement}
+	}
+
+	*d = PolicyDocument{Version: raw.Version, ID: raw.ID, Statement: statements}
+	return nil
+}
+
+// ParsePolicyDocument checks the policy document against the IAM policy
+// grammar, like ValidatePolicyDocument, and returns the typed document.
+func ParsePolicyDocument(policy string) (*PolicyDocument, error) {
+	if err := ValidatePolicyDocument(policy); err != nil {
+		return nil, err
+	}
+
+	doc := &PolicyDocument{}
+	if err := json.Unmarshal([]byte(policy), doc); err != nil {
+		return nil, fmt.Errorf(InvalidDocumentErr, err.Error())
+	}
+	return doc, nil
+}
+
+// JSON returns the policy document as a JSON string. Characters like <, >,
+// and & are written as they are, so they count as a single character
+// towards the size limits.
+func (d *PolicyDocument) JSON() (string, error) {
+	b, err := marshalJSON(d)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// marshalJSON is like json.Marshal, but doesn't escape <, >, and & as
+// \u003c, \u003e, and \u0026, which would count as six characters each
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// copy returns a deep copy of the statement
+func (s Statement) copy() Statement {
+	return s.mapStrings(func(str string) string { return str })
+}
+
+// mapStrings returns a copy of the statement where all strings, including
+// condition keys, have been passed through fn.
+func (s Statement) mapStrings(fn func(string) string) Statement {
+	s.Sid = fn(s.Sid)
+	s.Effect = fn(s.Effect)
+	s.Principal = s.Principal.mapStrings(fn)
+	s.NotPrincipal = s.NotPrincipal.mapStrings(fn)
+	s.Action = s.Action.mapStrings(fn)
+	s.NotAction = s.NotAction.mapStrings(fn)
+	s.Resource = s.Resource.mapStrings(fn)
+	s.NotResource = s.NotResource.mapStrings(fn)
+	s.Condition = s.Condition.mapStrings(fn)
+	return s
+}
+
+func (v Value) mapStrings(fn func(string) string) Value {
+	if v == nil {
+		return nil
+	}
+	out := make(Value, len(v))
+	for idx := range v {
+		out[idx] = fn(v[idx])
+	}
+	return out
+}
+
+func (p Principal) mapStrings(fn func(string) string) Principal {
+	if p == nil {
+		return nil
+	}
+	out := make(Principal, len(p))
+	for key, val := range p {
+		out[key] = val.mapStrings(fn)
+	}
+	return out
+}
+
+func (c Condition) mapStrings(fn func(string) string) Condition {
+	if c == nil {
+		return nil
+	}
+	out := make(Condition, len(c))
+	for operator, keys := range c {
+		out[operator] = make(map[string]Value, len(keys))
+		for key, val := range keys {
+			out[operator][fn(key)] = val.mapStrings(fn)
+		}
+	}
+	return out
+}
@@ -0,0 +1,375 @@
+package sampolicies
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// InvalidConditionValueErr is returned when a condition value or a context value can't be compared with the operator
+	InvalidConditionValueErr = "condition %s on %s can't compare %q"
+)
+
+// Decision is the outcome of evaluating a request against a policy.
+type Decision string
+
+const (
+	// DecisionAllow means a statement allows the request and no statement denies it
+	DecisionAllow Decision = "Allow"
+
+	// DecisionImplicitDeny means no statement allows or denies the request
+	DecisionImplicitDeny Decision = "ImplicitDeny"
+
+	// DecisionExplicitDeny means a Deny statement matches the request
+	DecisionExplicitDeny Decision = "ExplicitDeny"
+)
+
+// Evaluation is the result of evaluating a request against a policy.
+type Evaluation struct {
+	// Decision is the outcome of the evaluation
+	Decision Decision
+
+	// Statement is the statement that decided the outcome, which is nil for
+	// an ImplicitDeny
+	Statement *Statement
+}
+
+// negatedOperators maps the negated condition operators to the operator they
+// negate
+var negatedOperators = map[string]string{
+	ConditionStringNotEquals:           ConditionStringEquals,
+	ConditionStringNotEqualsIgnoreCase: ConditionStringEqualsIgnoreCase,
+	ConditionStringNotLike:             ConditionStringLike,
+	ConditionNumericNotEquals:          ConditionNumericEquals,
+	ConditionDateNotEquals:             ConditionDateEquals,
+	ConditionNotIPAddress:              ConditionIPAddress,
+	ConditionArnNotEquals:              ConditionArnEquals,
+	ConditionArnNotLike:                ConditionArnLike,
+}
+
+// Evaluate evaluates a request for the action on the resource against the
+// policy, the way AWS evaluates a single identity policy. The context holds
+// the values of the condition keys of the request, like aws:SourceVpce, and
+// of the policy variables. A matching Deny statement always wins over a
+// matching Allow statement. The Principal of the statements is ignored. An
+// error is returned if a condition can't be evaluated.
+func (d *PolicyDocument) Evaluate(action string, resource string, context map[string]Value) (Evaluation, error) {
+	var allow *Statement
+
+	for idx := range d.Statement {
+		s := &d.Statement[idx]
+
+		ok, err := s.matches(action, resource, context)
+		if err != nil {
+			return Evaluation{}, err
+		}
+		if !ok {
+			continue
+		}
+
+		if s.Effect == EffectDeny {
+			return Evaluation{Decision: DecisionExplicitDeny, Statement: s}, nil
+		}
+		if allow == nil {
+			allow = s
+		}
+	}
+
+	if allow != nil {
+		return Evaluation{Decision: DecisionAllow, Statement: allow}, nil
+	}
+	return Evaluation{Decision: DecisionImplicitDeny}, nil
+}
+
+// Evaluate creates the policy document and evaluates the request against it,
+// like PolicyDocument.Evaluate. This allows you to test the permissions a
+// factory grants.
+func (f *Factory) Evaluate(action string, resource string, context map[string]Value) (Evaluation, error) {
+	doc, err := f.GetPolicyDocument()
+	if err != nil {
+		return Evaluation{}, err
+	}
+	return doc.Evaluate(action, resource, context)
+}
+
+// EvaluatePolicy parses the policy document and evaluates the request
+// against it, like PolicyDocument.Evaluate.
+func EvaluatePolicy(policy string, action string, resource string, context map[string]Value) (Evaluation, error) {
+	doc, err := ParsePolicyDocument(policy)
+	if err != nil {
+		return Evaluation{}, err
+	}
+	return doc.Evaluate(action, resource, context)
+}
+
+// matches returns true if the statement applies to the request
+func (s *Statement) matches(action string, resource string, context map[string]Value) (bool, error) {
+	if len(s.Action) > 0 && !matchAny(s.Action, action, true, context) {
+		return false, nil
+	}
+	if len(s.NotAction) > 0 && matchAny(s.NotAction, action, true, context) {
+		return false, nil
+	}
+
+	if len(s.Resource) > 0 && !matchAny(s.Resource, resource, false, context) {
+		return false, nil
+	}
+	if len(s.NotResource) > 0 && matchAny(s.NotResource, resource, false, context) {
+		return false, nil
+	}
+
+	for operator, keys := range s.Condition {
+		for key, values := range keys {
+			ok, err := evaluateCondition(operator, key, values, context)
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+	}
+	return true, nil
+}
+
+// matchAny returns true if the value matches one of the patterns. A pattern
+// with a policy variable that isn't in the context never matches.
+func matchAny(patterns Value, value string, ignoreCase bool, context map[string]Value) bool {
+	for _, pattern := range patterns {
+		re, ok := variableRegexp(pattern, ignoreCase, context)
+		if ok && re.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// variableRegexp returns the regular expression for the IAM pattern, where
+// the policy variables are replaced with their value from the context. The
+// values, and the special variables ${*}, ${?}, and ${$}, are matched
+// literally. It returns false if a variable isn't in the context.
+func variableRegexp(pattern string, ignoreCase bool, context map[string]Value) (*regexp.Regexp, bool) {
+	var b strings.Builder
+	last := 0
+	for _, loc := range placeholderRegexp.FindAllStringSubmatchIndex(pattern, -1) {
+		b.WriteString(valueExpr(pattern[last:loc[0]]))
+		last = loc[1]
+
+		value, ok := variableValue(pattern[loc[2]:loc[3]], context)
+		if !ok {
+			return nil, false
+		}
+		b.WriteString(regexp.QuoteMeta(value))
+	}
+	b.WriteString(valueExpr(pattern[last:]))
+
+	return compileWildcard(b.String(), ignoreCase), true
+}
+
+// expandVariables replaces the IAM policy variables in the string with their
+// value from the context. It returns false if a variable isn't in the
+// context.
+func expandVariables(s string, context map[string]Value) (string, bool) {
+	ok := true
+	out := placeholderRegexp.ReplaceAllStringFunc(s, func(match string) string {
+		value, found := variableValue(match[2:len(match)-1], context)
+		if !found {
+			ok = false
+			return match
+		}
+		return value
+	})
+	return out, ok
+}
+
+// variableValue returns the value of the IAM policy variable from the
+// context, or the literal character for ${*}, ${?}, and ${$}.
+func variableValue(name string, context map[string]Value) (string, bool) {
+	switch name {
+	case "*", "?", "$":
+		return name, true
+	}
+
+	values, ok := lookupContext(context, name)
+	if !ok || len(values) != 1 {
+		return "", false
+	}
+	return values[0], true
+}
+
+// lookupContext returns the values of the condition key, which is case
+// insensitive.
+func lookupContext(context map[string]Value, key string) (Value, bool) {
+	if values, ok := context[key]; ok {
+		return values, true
+	}
+	for name, values := range context {
+		if strings.EqualFold(name, key) {
+			return values, true
+		}
+	}
+	return nil, false
+}
+
+// evaluateCondition evaluates a single condition key of a statement. It
+// supports the IfExists suffix and the ForAllValues: and ForAnyValue: set
+// operators.
+func evaluateCondition(operator string, key string, values Value, context map[string]Value) (bool, error) {
+	base := operator
+	set := ""
+	for _, prefix := range []string{"ForAllValues:", "ForAnyValue:"} {
+		if strings.HasPrefix(base, prefix) {
+			set = prefix
+			base = strings.TrimPrefix(base, prefix)
+		}
+	}
+
+	ifExists := false
+	if base != ConditionNull && strings.HasSuffix(base, "IfExists") {
+		ifExists = true
+		base = strings.TrimSuffix(base, "IfExists")
+	}
+
+	if !conditionOperators[base] {
+		return false, fmt.Errorf("unknown condition operator %s", operator)
+	}
+
+	requestValues, present := lookupContext(context, key)
+	present = present && len(requestValues) > 0
+
+	if base == ConditionNull {
+		for _, val := range values {
+			if strings.EqualFold(val, strconv.FormatBool(!present)) {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	positive, negated := negatedOperators[base]
+	if !negated {
+		positive = base
+	}
+
+	if !present {
+		switch {
+		case ifExists, set == "ForAllValues:":
+			return true, nil
+		case set == "ForAnyValue:":
+			return false, nil
+		}
+		return negated, nil
+	}
+
+	// matches returns true if the request value matches one of the values
+	// of the condition, with the negation applied
+	matches := func(requestValue string) (bool, error) {
+		for _, val := range values {
+			ok, err := compareCondition(positive, key, val, requestValue, context)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return !negated, nil
+			}
+		}
+		return negated, nil
+	}
+
+	for _, requestValue := range requestValues {
+		ok, err := matches(requestValue)
+		if err != nil {
+			return false, err
+		}
+		if set == "ForAllValues:" && !ok {
+			return false, nil
+		}
+		if set != "ForAllValues:" && ok {
+			return true, nil
+		}
+	}
+	return set == "ForAllValues:", nil
+}
+
+// compareCondition compares the value of the request with a value of the
+// condition using a condition operator that isn't negated.
+func compareCondition(operator string, key string, conditionValue string, requestValue string, context map[string]Value) (bool, error) {
+	// Patterns match the values of policy variables literally
+	switch operator {
+	case ConditionStringLike, ConditionArnEquals, ConditionArnLike:
+		re, ok := variableRegexp(conditionValue, false, context)
+		return ok && re.MatchString(requestValue), nil
+	}
+
+	conditionValue, ok := expandVariables(conditionValue, context)
+	if !ok {
+		return false, nil
+	}
+
+	switch operator {
+	case ConditionStringEquals, ConditionBinaryEquals:
+		return conditionValue == requestValue, nil
+	case ConditionStringEqualsIgnoreCase, ConditionBool:
+		return strings.EqualFold(conditionValue, requestValue), nil
+	case ConditionNumericEquals, ConditionNumericLessThan, ConditionNumericLessThanEquals,
+		ConditionNumericGreaterThan, ConditionNumericGreaterThanEquals:
+		a, errA := strconv.ParseFloat(requestValue, 64)
+		b, errB := strconv.ParseFloat(conditionValue, 64)
+		if errA != nil || errB != nil {
+			return false, fmt.Errorf(InvalidConditionValueErr, operator, key, requestValue)
+		}
+		return compareOrdered(operator, a-b), nil
+	case ConditionDateEquals, ConditionDateLessThan, ConditionDateLessThanEquals,
+		ConditionDateGreaterThan, ConditionDateGreaterThanEquals:
+		a, errA := parseDate(requestValue)
+		b, errB := parseDate(conditionValue)
+		if errA != nil || errB != nil {
+			return false, fmt.Errorf(InvalidConditionValueErr, operator, key, requestValue)
+		}
+		return compareOrdered(operator, float64(a.Sub(b))), nil
+	case ConditionIPAddress:
+		ip := net.ParseIP(requestValue)
+		if ip == nil {
+			return false, fmt.Errorf(InvalidConditionValueErr, operator, key, requestValue)
+		}
+		if !strings.Contains(conditionValue, "/") {
+			return ip.Equal(net.ParseIP(conditionValue)), nil
+		}
+		_, network, err := net.ParseCIDR(conditionValue)
+		if err != nil {
+			return false, fmt.Errorf(InvalidConditionValueErr, operator, key, conditionValue)
+		}
+		return network.Contains(ip), nil
+	}
+	return false, fmt.Errorf("unknown condition operator %s", operator)
+}
+
+// compareOrdered returns the result of a numeric or date operator, given the
+// difference between the value of the request and the value of the condition
+func compareOrdered(operator string, diff float64) bool {
+	switch {
+	case strings.HasSuffix(operator, "LessThan"):
+		return diff < 0
+	case strings.HasSuffix(operator, "LessThanEquals"):
+		return diff <= 0
+	case strings.HasSuffix(operator, "GreaterThan"):
+		return diff > 0
+	case strings.HasSuffix(operator, "GreaterThanEquals"):
+		return diff >= 0
+	}
+	return diff == 0
+}
+
+// parseDate parses a date in ISO 8601 format or as seconds since the epoch
+func parseDate(s string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05Z0700", "2006-01-02"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%q is not a date", s)
+}
@@ -0,0 +1,113 @@
+package sampolicies
+
+import (
+	"testing"
+)
+
+func TestEvaluatePolicy(t *testing.T) {
+	const bucket = `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Action":"s3:*","Resource":"arn:aws:s3:::data/*"},
+		{"Effect":"Deny","Action":"s3:DeleteObject","Resource":"arn:aws:s3:::data/locked/*"}
+	]}`
+	const notElements = `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","NotAction":"iam:*","NotResource":"arn:aws:s3:::secret/*"}
+	]}`
+	const negated = `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Action":"sqs:SendMessage","Resource":"*","Condition":{"StringNotEquals":{"aws:PrincipalTag/team":"guest"}}}
+	]}`
+	const ifExists = `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Action":"sqs:SendMessage","Resource":"*","Condition":{"StringEqualsIfExists":{"aws:SourceVpce":"vpce-1"}}}
+	]}`
+	const forAll = `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":"*","Condition":{"ForAllValues:StringEquals":{"dynamodb:Attributes":["id","name"]}}}
+	]}`
+	const forAny = `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Action":"dynamodb:GetItem","Resource":"*","Condition":{"ForAnyValue:StringEquals":{"dynamodb:Attributes":["id","name"]}}}
+	]}`
+	const singleChar = `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::data/file?.txt"}
+	]}`
+
+	tests := []struct {
+		name     string
+		policy   string
+		action   string
+		resource string
+		context  map[string]Value
+		decision Decision
+	}{
+		{"allow", bucket, "s3:DeleteObject", "arn:aws:s3:::data/open/key", nil, DecisionAllow},
+		{"deny wins over allow", bucket, "s3:DeleteObject", "arn:aws:s3:::data/locked/key", nil, DecisionExplicitDeny},
+		{"deny only matches its action", bucket, "s3:GetObject", "arn:aws:s3:::data/locked/key", nil, DecisionAllow},
+		{"no matching statement", bucket, "s3:GetObject", "arn:aws:s3:::other/key", nil, DecisionImplicitDeny},
+		{"action case is ignored", bucket, "S3:getobject", "arn:aws:s3:::data/key", nil, DecisionAllow},
+
+		{"NotAction and NotResource allow others", notElements, "s3:GetObject", "arn:aws:s3:::data/key", nil, DecisionAllow},
+		{"NotAction excludes action", notElements, "iam:CreateUser", "arn:aws:s3:::data/key", nil, DecisionImplicitDeny},
+		{"NotResource excludes resource", notElements, "s3:GetObject", "arn:aws:s3:::secret/key", nil, DecisionImplicitDeny},
+
+		{"negated operator with other value", negated, "sqs:SendMessage", "*", map[string]Value{"aws:PrincipalTag/team": {"payments"}}, DecisionAllow},
+		{"negated operator with value", negated, "sqs:SendMessage", "*", map[string]Value{"aws:PrincipalTag/team": {"guest"}}, DecisionImplicitDeny},
+		{"negated operator with missing key", negated, "sqs:SendMessage", "*", nil, DecisionAllow},
+
+		{"IfExists with matching key", ifExists, "sqs:SendMessage", "*", map[string]Value{"aws:SourceVpce": {"vpce-1"}}, DecisionAllow},
+		{"IfExists with other value", ifExists, "sqs:SendMessage", "*", map[string]Value{"aws:SourceVpce": {"vpce-2"}}, DecisionImplicitDeny},
+		{"IfExists with missing key", ifExists, "sqs:SendMessage", "*", nil, DecisionAllow},
+
+		{"ForAllValues with subset", forAll, "dynamodb:GetItem", "*", map[string]Value{"dynamodb:Attributes": {"id"}}, DecisionAllow},
+		{"ForAllValues with other value", forAll, "dynamodb:GetItem", "*", map[string]Value{"dynamodb:Attributes": {"id", "secret"}}, DecisionImplicitDeny},
+		{"ForAllValues with empty key", forAll, "dynamodb:GetItem", "*", map[string]Value{"dynamodb:Attributes": {}}, DecisionAllow},
+		{"ForAllValues with missing key", forAll, "dynamodb:GetItem", "*", nil, DecisionAllow},
+		{"ForAnyValue with one value", forAny, "dynamodb:GetItem", "*", map[string]Value{"dynamodb:Attributes": {"secret", "name"}}, DecisionAllow},
+		{"ForAnyValue with other values", forAny, "dynamodb:GetItem", "*", map[string]Value{"dynamodb:Attributes": {"secret"}}, DecisionImplicitDeny},
+		{"ForAnyValue with empty key", forAny, "dynamodb:GetItem", "*", map[string]Value{"dynamodb:Attributes": {}}, DecisionImplicitDeny},
+		{"ForAnyValue with missing key", forAny, "dynamodb:GetItem", "*", nil, DecisionImplicitDeny},
+
+		{"? matches a character", singleChar, "s3:GetObject", "arn:aws:s3:::data/file1.txt", nil, DecisionAllow},
+		{"? matches a literal *", singleChar, "s3:GetObject", "arn:aws:s3:::data/file*.txt", nil, DecisionAllow},
+		{"? matches one character", singleChar, "s3:GetObject", "arn:aws:s3:::data/file12.txt", nil, DecisionImplicitDeny},
+	}
+
+	for _, tt := range tests {
+		e, err := EvaluatePolicy(tt.policy, tt.action, tt.resource, tt.context)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if e.Decision != tt.decision {
+			t.Errorf("%s: got %s, want %s", tt.name, e.Decision, tt.decision)
+		}
+	}
+}
+
+func TestEvaluatePolicyVariables(t *testing.T) {
+	const policy = `{"Version":"2012-10-17","Statement":[
+		{"Effect":"Allow","Action":"s3:GetObject","Resource":"arn:aws:s3:::home/${aws:username}/*"},
+		{"Effect":"Allow","Action":"s3:PutObject","Resource":"arn:aws:s3:::home/${*}"}
+	]}`
+
+	tests := []struct {
+		name     string
+		action   string
+		resource string
+		context  map[string]Value
+		decision Decision
+	}{
+		{"variable from context", "s3:GetObject", "arn:aws:s3:::home/alice/key", map[string]Value{"aws:username": {"alice"}}, DecisionAllow},
+		{"variable of another user", "s3:GetObject", "arn:aws:s3:::home/bob/key", map[string]Value{"aws:username": {"alice"}}, DecisionImplicitDeny},
+		{"missing variable never matches", "s3:GetObject", "arn:aws:s3:::home/alice/key", nil, DecisionImplicitDeny},
+		{"${*} matches a literal *", "s3:PutObject", "arn:aws:s3:::home/*", nil, DecisionAllow},
+		{"${*} isn't a wildcard", "s3:PutObject", "arn:aws:s3:::home/key", nil, DecisionImplicitDeny},
+	}
+
+	for _, tt := range tests {
+		e, err := EvaluatePolicy(policy, tt.action, tt.resource, tt.context)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if e.Decision != tt.decision {
+			t.Errorf("%s: got %s, want %s", tt.name, e.Decision, tt.decision)
+		}
+	}
+}
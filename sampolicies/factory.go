@@ -2,14 +2,13 @@
 // the permissions of your Lambda functions to the resources that are used by your application.
 package sampolicies
 
+//go:generate go run ../cmd/policy-generator.go -input ../cmd/policy_templates.json -output policies.go
+
 import (
 	"errors"
 	"fmt"
 	"strings"
-)
-
-const (
-	iamTemplate = `{ "Version": "2012-10-17", "Statement": [ %s ] }`
+	"unicode/utf8"
 )
 
 const (
@@ -23,14 +22,35 @@ const (
 	RegionMissingErr = "factory is missing required variable region"
 )
 
+const (
+	// customPolicyName is the name used for statements added with AddStatements
+	customPolicyName = "custom statements"
+
+	// importedPolicyName is the name used for imported policy documents without an Id
+	importedPolicyName = "imported policy"
+)
+
 // Factory is the main struct to create all new policies.
 // It also has methods to get the IAM statement and add new
 // policies to the array.
 type Factory struct {
-	policies  []string
-	partition string
-	region    string
-	accountID string
+	policies     []policy
+	templates    map[string]Template
+	variables    map[string]string
+	condition    Condition
+	conditionErr error
+	kind         PolicyKind
+	partition    string
+	region       string
+	accountID    string
+}
+
+// policy is the set of statements a single template, or a call to
+// AddStatements, added to the factory.
+type policy struct {
+	name       string
+	statements []Statement
+	params     map[string]string
 }
 
 // NewFactory returns a new Factory pointer that can be chained with builder
@@ -40,7 +60,8 @@ func NewFactory() *Factory {
 }
 
 // WithPartition sets the AWS partition to use and returns a pointer to the
-// existing resource to allow chaining.
+// existing resource to allow chaining. If no partition is set, it's inferred
+// from the region.
 func (f *Factory) WithPartition(partition string) *Factory {
 	f.partition = partition
 	return f
@@ -62,33 +83,180 @@ func (f *Factory) WithAccountID(accountID string) *Factory {
 
 // GetPolicyStatement creates the AWS IAM policy statement by linking
 // together the policies that have been added so far and substituting the
-// partition, region, and accountID. If any of the fields are missing, an
-// error will be thrown.
+// partition, region, and accountID. If any of the fields are missing, the
+// resulting document isn't a valid IAM policy, or it exceeds the size limit
+// of the kind of policy the factory builds, an error will be thrown. Use GetPolicyStatements
+// to split a large policy over multiple documents. As IAM rejects a policy
+// without statements, a factory without policies results in an error.
 func (f *Factory) GetPolicyStatement() (string, error) {
+	doc, err := f.GetPolicyDocument()
+	if err != nil {
+		return "", err
+	}
+
+	policy, err := doc.JSON()
+	if err != nil {
+		return "", err
+	}
+
+	// Validate the assembled document before it's handed to AWS
+	if err := ValidatePolicyDocumentKind(policy, f.Kind()); err != nil {
+		return "", err
+	}
+
+	if size, maxSize := utf8.RuneCountInString(policy), f.Kind().MaxSize(); size > maxSize {
+		return "", fmt.Errorf(PolicyTooLargeErr, size, limitName(maxSize), maxSize)
+	}
+
+	return policy, nil
+}
+
+// GetPolicyDocument creates the typed AWS IAM policy document from the
+// policies that have been added so far, substituting the partition, region,
+// accountID, and custom variables. If any of the fields are missing, a
+// policy has placeholders without a value, or a policy results in statements
+// that don't follow the IAM policy grammar or aren't allowed in the kind of
+// policy the factory builds, an error naming that policy will be thrown. IAM
+// policy variables, like ${aws:username}, are left intact. Duplicate and
+// overlapping statements are merged, and the statements are sorted, so the
+// same policies always result in the same document.
+func (f *Factory) GetPolicyDocument() (*PolicyDocument, error) {
 	// Perform checks
 	if len(f.accountID) == 0 {
-		return "", errors.New(AccountIDMissingErr)
+		return nil, errors.New(AccountIDMissingErr)
 	}
 
 	if len(f.region) == 0 {
-		return "", errors.New(RegionMissingErr)
+		return nil, errors.New(RegionMissingErr)
+	}
+
+	if len(f.Partition()) == 0 {
+		return nil, errors.New(PartitionMissingErr)
+	}
+
+	if f.conditionErr != nil {
+		return nil, f.conditionErr
 	}
 
-	if len(f.partition) == 0 {
-		return "", errors.New(PartitionMissingErr)
+	// Template parameters take precedence over the AWS pseudo parameters,
+	// which take precedence over the custom variables
+	values := make(map[string]string)
+	for key, val := range f.variables {
+		values[key] = val
+	}
+	for key, val := range f.pseudoParameterValues() {
+		values[key] = val
 	}
 
-	// Replace AWS placeholders
-	t := fmt.Sprintf(iamTemplate, strings.Join(f.policies, ","))
-	t = strings.ReplaceAll(t, "${AWS::Partition}", f.partition)
-	t = strings.ReplaceAll(t, "${AWS::Region}", f.region)
-	t = strings.ReplaceAll(t, "${AWS::AccountId}", f.accountID)
+	doc := &PolicyDocument{
+		Version:   PolicyVersion,
+		Statement: make([]Statement, 0),
+	}
+
+	unresolved := make([]string, 0)
+
+	for _, p := range f.policies {
+		conditioned, err := addCondition(p.name, p.statements, f.condition)
+		if err != nil {
+			return nil, err
+		}
+		p.statements = conditioned
+
+		statements, missing := p.resolve(values)
+		if len(missing) > 0 {
+			unresolved = append(unresolved, fmt.Sprintf("%s in %s", formatPlaceholders(missing), p.name))
+			continue
+		}
+
+		if err := validatePolicy(p.name, statements, f.Kind()); err != nil {
+			return nil, err
+		}
+
+		doc.Statement = append(doc.Statement, statements...)
+	}
+
+	if len(unresolved) > 0 {
+		return nil, fmt.Errorf(UnresolvedPlaceholdersErr, strings.Join(unresolved, "; "))
+	}
+
+	doc.Statement = optimize(doc.Statement)
 
 	// Return the policy document
-	return t, nil
+	return doc, nil
+}
+
+// Statements returns a copy of the statements that have been added so far,
+// with the template parameters substituted but before the partition,
+// region, accountID, and custom variables are substituted.
+func (f *Factory) Statements() []Statement {
+	statements := make([]Statement, 0)
+	for _, p := range f.policies {
+		s, _ := p.resolve(nil)
+		statements = append(statements, s...)
+	}
+	return statements
+}
+
+// AddStatements adds custom statements to the factory. The statements can
+// use the same AWS pseudo parameters and custom variables as the templates.
+func (f *Factory) AddStatements(statements ...Statement) {
+	f.addPolicy(customPolicyName, nil, statements...)
 }
 
 // ClearPolicies removes all policies so you can begin with a clean slate
 func (f *Factory) ClearPolicies() {
 	f.policies = nil
 }
+
+// clone returns a copy of the factory with its own policies and variables,
+// so the copy can be changed without changing the factory
+func (f *Factory) clone() *Factory {
+	out := *f
+	out.policies = append([]policy(nil), f.policies...)
+	out.variables = make(map[string]string, len(f.variables))
+	for key, val := range f.variables {
+		out.variables[key] = val
+	}
+	return &out
+}
+
+// addPolicy adds the statements of a template to the factory under the name
+// of the template. The template parameters are substituted when the policy
+// document is created.
+func (f *Factory) addPolicy(name string, params map[string]string, statements ...Statement) {
+	p := policy{
+		name:       name,
+		statements: make([]Statement, len(statements)),
+		params:     make(map[string]string, len(params)),
+	}
+
+	for idx := range statements {
+		p.statements[idx] = statements[idx].copy()
+	}
+	for key, val := range params {
+		p.params[key] = val
+	}
+
+	f.policies = append(f.policies, p)
+}
+
+// resolve substitutes the template parameters and the given values in the
+// statements of the policy, and returns the placeholders that have no value.
+func (p policy) resolve(values map[string]string) ([]Statement, []string) {
+	merged := make(map[string]string, len(values)+len(p.params))
+	for key, val := range values {
+		merged[key] = val
+	}
+	for key, val := range p.params {
+		merged[key] = val
+	}
+
+	statements := make([]Statement, len(p.statements))
+	unresolved := make([]string, 0)
+	for idx := range p.statements {
+		var missing []string
+		statements[idx], missing = substitute(p.statements[idx], merged)
+		unresolved = append(unresolved, missing...)
+	}
+	return statements, unresolved
+}
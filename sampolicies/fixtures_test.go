@@ -0,0 +1,27 @@
+package sampolicies
+
+import "fmt"
+
+// sizedFactory returns a factory of the kind with count statements of about
+// 100 characters each: accounts that can assume the role for a TrustPolicy,
+// principals that can read a bucket for a ResourcePolicy, and queues to send
+// messages to otherwise.
+func sizedFactory(kind PolicyKind, count int) *Factory {
+	f := NewFactory().WithKind(kind).WithAccountID("123456789012").WithRegion("us-east-1")
+	for idx := 0; idx < count; idx++ {
+		switch kind {
+		case TrustPolicy:
+			f.AddAssumeRoleAccount(fmt.Sprintf("100000000%03d", idx), "", false)
+		case ResourcePolicy:
+			f.AddStatements(Statement{
+				Effect:    EffectAllow,
+				Principal: Principal{"AWS": Value{fmt.Sprintf("arn:aws:iam::100000000%03d:root", idx)}},
+				Action:    Value{"s3:GetObject"},
+				Resource:  Value{fmt.Sprintf("arn:aws:s3:::bucket/%03d/*", idx)},
+			})
+		default:
+			f.AddSQSSendMessagePolicy(fmt.Sprintf("queue-%03d", idx))
+		}
+	}
+	return f
+}
@@ -0,0 +1,122 @@
+package sampolicies
+
+import (
+	"fmt"
+)
+
+const (
+	// InvalidGuardrailErr is returned when a guardrail is added that isn't valid
+	InvalidGuardrailErr = "guardrail %s is invalid: %s"
+)
+
+// globalActions are the actions of global services, which are served from a
+// single region and should never be denied based on the requested region
+var globalActions = Value{
+	"a4b:*", "acm:*", "aws-marketplace-management:*", "aws-marketplace:*", "aws-portal:*", "budgets:*",
+	"ce:*", "chime:*", "cloudfront:*", "config:*", "cur:*", "directconnect:*", "ec2:DescribeRegions",
+	"ec2:DescribeTransitGateways", "ec2:DescribeVpnGateways", "fms:*", "globalaccelerator:*", "health:*",
+	"iam:*", "importexport:*", "kms:*", "mobileanalytics:*", "networkmanager:*", "organizations:*",
+	"pricing:*", "route53:*", "route53domains:*", "s3:GetAccountPublic*", "s3:ListAllMyBuckets",
+	"s3:PutAccountPublic*", "shield:*", "sts:*", "support:*", "trustedadvisor:*", "waf-regional:*",
+	"waf:*", "wafv2:*", "wellarchitected:*",
+}
+
+// Guardrail is a named set of Deny statements that can be added to a
+// Factory, so every policy created by it carries the same baseline
+// protections. Explicit denies always win over the grants of the policy.
+type Guardrail struct {
+	Name        string
+	Description string
+	Statements  []Statement
+}
+
+// DenyInsecureTransport returns a guardrail that denies all access to S3 over
+// plain HTTP.
+func DenyInsecureTransport() Guardrail {
+	return Guardrail{
+		Name:        "DenyInsecureTransport",
+		Description: "Denies access to S3 over plain HTTP",
+		Statements: []Statement{
+			{
+				Sid:       "DenyInsecureTransport",
+				Effect:    EffectDeny,
+				Action:    Value{"s3:*"},
+				Resource:  Value{"*"},
+				Condition: Bool("aws:SecureTransport", false),
+			},
+		},
+	}
+}
+
+// DenyOutsideRegions returns a guardrail that denies all actions outside the
+// approved regions, except the actions of global services like IAM,
+// CloudFront, and Route 53.
+func DenyOutsideRegions(regions ...string) Guardrail {
+	return Guardrail{
+		Name:        "DenyOutsideRegions",
+		Description: "Denies all actions outside the approved regions",
+		Statements: []Statement{
+			{
+				Sid:       "DenyOutsideRegions",
+				Effect:    EffectDeny,
+				NotAction: append(Value(nil), globalActions...),
+				Resource:  Value{"*"},
+				Condition: StringNotEquals("aws:RequestedRegion", regions...),
+			},
+		},
+	}
+}
+
+// DenyIAM returns a guardrail that denies all IAM actions, so a role can't
+// change its own permissions or create new principals.
+func DenyIAM() Guardrail {
+	return Guardrail{
+		Name:        "DenyIAM",
+		Description: "Denies all IAM actions",
+		Statements: []Statement{
+			{
+				Sid:      "DenyIAM",
+				Effect:   EffectDeny,
+				Action:   Value{"iam:*"},
+				Resource: Value{"*"},
+			},
+		},
+	}
+}
+
+// AddGuardrails adds the Deny statements of the guardrails to the factory.
+// The statements can use the same AWS pseudo parameters and custom variables
+// as the templates. An error is returned, and no guardrail is added, if a
+// guardrail has no statements, has a statement that isn't a Deny, or
+// doesn't follow the IAM policy grammar.
+func (f *Factory) AddGuardrails(guardrails ...Guardrail) error {
+	for _, g := range guardrails {
+		if err := g.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, g := range guardrails {
+		f.addPolicy(g.Name, nil, g.Statements...)
+	}
+	return nil
+}
+
+// validate checks that the guardrail only has Deny statements that follow the
+// IAM policy grammar.
+func (g Guardrail) validate() error {
+	if len(g.Statements) == 0 {
+		return fmt.Errorf(InvalidGuardrailErr, g.Name, "guardrail has no statements")
+	}
+
+	for idx, s := range g.Statements {
+		if s.Effect != EffectDeny {
+			return fmt.Errorf(InvalidGuardrailErr, g.Name, fmt.Sprintf("statement %d has Effect %s instead of %s", idx, s.Effect, EffectDeny))
+		}
+	}
+
+	if err := validateStatements(g.Statements, ""); err != nil {
+		return fmt.Errorf(InvalidGuardrailErr, g.Name, err.Error())
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package sampolicies
+
+import "testing"
+
+func TestGuardrailPresets(t *testing.T) {
+	tests := []struct {
+		name      string
+		guardrail Guardrail
+		action    string
+		resource  string
+		context   map[string]Value
+		decision  Decision
+	}{
+		{
+			name:      "S3 over HTTP",
+			guardrail: DenyInsecureTransport(),
+			action:    "s3:GetObject",
+			resource:  "arn:aws:s3:::uploads/a.txt",
+			context:   map[string]Value{"aws:SecureTransport": {"false"}},
+			decision:  DecisionExplicitDeny,
+		},
+		{
+			name:      "S3 over HTTPS",
+			guardrail: DenyInsecureTransport(),
+			action:    "s3:GetObject",
+			resource:  "arn:aws:s3:::uploads/a.txt",
+			context:   map[string]Value{"aws:SecureTransport": {"true"}},
+			decision:  DecisionAllow,
+		},
+		{
+			name:      "other services over HTTP",
+			guardrail: DenyInsecureTransport(),
+			action:    "sqs:SendMessage",
+			resource:  "arn:aws:sqs:us-east-1:123456789012:jobs",
+			context:   map[string]Value{"aws:SecureTransport": {"false"}},
+			decision:  DecisionAllow,
+		},
+		{
+			name:      "approved region",
+			guardrail: DenyOutsideRegions("us-east-1", "eu-west-1"),
+			action:    "dynamodb:GetItem",
+			resource:  "arn:aws:dynamodb:eu-west-1:123456789012:table/orders",
+			context:   map[string]Value{"aws:RequestedRegion": {"eu-west-1"}},
+			decision:  DecisionAllow,
+		},
+		{
+			name:      "other region",
+			guardrail: DenyOutsideRegions("us-east-1", "eu-west-1"),
+			action:    "dynamodb:GetItem",
+			resource:  "arn:aws:dynamodb:ap-south-1:123456789012:table/orders",
+			context:   map[string]Value{"aws:RequestedRegion": {"ap-south-1"}},
+			decision:  DecisionExplicitDeny,
+		},
+		{
+			name:      "global service in another region",
+			guardrail: DenyOutsideRegions("eu-west-1"),
+			action:    "iam:GetRole",
+			resource:  "arn:aws:iam::123456789012:role/app",
+			context:   map[string]Value{"aws:RequestedRegion": {"us-east-1"}},
+			decision:  DecisionAllow,
+		},
+		{
+			name:      "global action of a regional service in another region",
+			guardrail: DenyOutsideRegions("eu-west-1"),
+			action:    "s3:ListAllMyBuckets",
+			resource:  "*",
+			context:   map[string]Value{"aws:RequestedRegion": {"us-east-1"}},
+			decision:  DecisionAllow,
+		},
+		{
+			name:      "regional action of a service with global actions in another region",
+			guardrail: DenyOutsideRegions("eu-west-1"),
+			action:    "ec2:RunInstances",
+			resource:  "*",
+			context:   map[string]Value{"aws:RequestedRegion": {"us-east-1"}},
+			decision:  DecisionExplicitDeny,
+		},
+		{
+			name:      "IAM",
+			guardrail: DenyIAM(),
+			action:    "iam:PutRolePolicy",
+			resource:  "arn:aws:iam::123456789012:role/app",
+			decision:  DecisionExplicitDeny,
+		},
+		{
+			name:      "other services than IAM",
+			guardrail: DenyIAM(),
+			action:    "sts:AssumeRole",
+			resource:  "arn:aws:iam::123456789012:role/app",
+			decision:  DecisionAllow,
+		},
+	}
+
+	for _, tt := range tests {
+		f := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1")
+		f.AddStatements(Statement{Effect: EffectAllow, Action: Value{"*"}, Resource: Value{"*"}})
+		if err := f.AddGuardrails(tt.guardrail); err != nil {
+			t.Fatalf("%s: %s", tt.name, err)
+		}
+
+		e, err := f.Evaluate(tt.action, tt.resource, tt.context)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if e.Decision != tt.decision {
+			t.Errorf("%s: got %s, want %s", tt.name, e.Decision, tt.decision)
+		}
+		if e.Decision == DecisionExplicitDeny && e.Statement.Sid != tt.guardrail.Name {
+			t.Errorf("%s: denied by %q, want %s", tt.name, e.Statement.Sid, tt.guardrail.Name)
+		}
+	}
+}
+
+func TestAddGuardrailsRejectsInvalidGuardrails(t *testing.T) {
+	tests := []struct {
+		name      string
+		guardrail Guardrail
+	}{
+		{"no statements", Guardrail{Name: "Empty"}},
+		{"allow statement", Guardrail{Name: "Allow", Statements: []Statement{{Effect: EffectAllow, Action: Value{"s3:*"}, Resource: Value{"*"}}}}},
+		{"invalid statement", Guardrail{Name: "Invalid", Statements: []Statement{{Effect: EffectDeny, Resource: Value{"*"}}}}},
+	}
+
+	for _, tt := range tests {
+		f := NewFactory()
+		if err := f.AddGuardrails(DenyIAM(), tt.guardrail); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+		if len(f.policies) != 0 {
+			t.Errorf("%s: added %d policies", tt.name, len(f.policies))
+		}
+	}
+}
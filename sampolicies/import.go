@@ -0,0 +1,80 @@
+package sampolicies
+
+import (
+	"errors"
+	"io/ioutil"
+
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/iam"
+)
+
+const (
+	// PolicyDocumentResultMissingErr is returned when a nil GetPolicyDocumentResult is imported
+	PolicyDocumentResultMissingErr = "GetPolicyDocumentResult is missing"
+)
+
+// ReadPolicyDocument reads the policy document from a JSON file and parses it
+// like ParsePolicyDocument.
+func ReadPolicyDocument(path string) (*PolicyDocument, error) {
+	policy, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePolicyDocument(string(policy))
+}
+
+// PolicyDocumentFromResult parses the JSON of the policy document created by
+// iam.GetPolicyDocument like ParsePolicyDocument.
+func PolicyDocumentFromResult(result *iam.GetPolicyDocumentResult) (*PolicyDocument, error) {
+	if result == nil {
+		return nil, errors.New(PolicyDocumentResultMissingErr)
+	}
+	return ParsePolicyDocument(result.Json)
+}
+
+// AddPolicyDocument adds the statements of an existing policy document to
+// the factory, next to its templates and custom statements. The statements
+// are named after the Id of the document in errors, and can use the same
+// AWS pseudo parameters and custom variables as the templates.
+func (f *Factory) AddPolicyDocument(doc *PolicyDocument) {
+	name := doc.ID
+	if len(name) == 0 {
+		name = importedPolicyName
+	}
+	f.addPolicy(name, nil, doc.Statement...)
+}
+
+// ImportPolicy parses the policy document and adds its statements to the
+// factory like AddPolicyDocument. Action, Resource, and the other elements
+// can be a single string or an array, and Statement can be a single
+// statement or an array. An error is returned if the document doesn't follow
+// the IAM policy grammar.
+func (f *Factory) ImportPolicy(policy string) error {
+	doc, err := ParsePolicyDocument(policy)
+	if err != nil {
+		return err
+	}
+	f.AddPolicyDocument(doc)
+	return nil
+}
+
+// ImportPolicyFile reads the policy document from a JSON file and adds its
+// statements to the factory like ImportPolicy.
+func (f *Factory) ImportPolicyFile(path string) error {
+	doc, err := ReadPolicyDocument(path)
+	if err != nil {
+		return err
+	}
+	f.AddPolicyDocument(doc)
+	return nil
+}
+
+// ImportPolicyDocumentResult adds the statements of the policy document
+// created by iam.GetPolicyDocument to the factory like ImportPolicy.
+func (f *Factory) ImportPolicyDocumentResult(result *iam.GetPolicyDocumentResult) error {
+	doc, err := PolicyDocumentFromResult(result)
+	if err != nil {
+		return err
+	}
+	f.AddPolicyDocument(doc)
+	return nil
+}
@@ -0,0 +1,146 @@
+package sampolicies
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/iam"
+)
+
+// publicRead is the statement of testdata/bucket_policy.json
+var publicRead = Statement{
+	Sid:       "PublicRead",
+	Effect:    EffectAllow,
+	Principal: Principal{"AWS": Value{"*"}},
+	Action:    Value{"s3:GetObject"},
+	Resource:  Value{"arn:${AWS::Partition}:s3:::assets-${AWS::AccountId}/*"},
+}
+
+func TestReadPolicyDocument(t *testing.T) {
+	doc, err := ReadPolicyDocument("testdata/bucket_policy.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &PolicyDocument{Version: "2008-10-17", ID: "PublicAssets", Statement: []Statement{publicRead}}
+	if !reflect.DeepEqual(doc, want) {
+		t.Errorf("got %+v, want %+v", doc, want)
+	}
+
+	if _, err := ReadPolicyDocument("testdata/missing.json"); err == nil {
+		t.Error("got no error for a missing file")
+	}
+}
+
+func TestPolicyDocumentFromResult(t *testing.T) {
+	doc, err := PolicyDocumentFromResult(&iam.GetPolicyDocumentResult{
+		Json: `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["sqs:SendMessage"],"Resource":"arn:aws:sqs:us-east-1:123456789012:jobs"}]}`,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Statement{{Effect: EffectAllow, Action: Value{"sqs:SendMessage"}, Resource: Value{"arn:aws:sqs:us-east-1:123456789012:jobs"}}}
+	if !reflect.DeepEqual(doc.Statement, want) {
+		t.Errorf("got %+v, want %+v", doc.Statement, want)
+	}
+
+	if _, err := PolicyDocumentFromResult(nil); err == nil || err.Error() != PolicyDocumentResultMissingErr {
+		t.Errorf("got error %v, want %s", err, PolicyDocumentResultMissingErr)
+	}
+}
+
+func TestImportPolicy(t *testing.T) {
+	tests := []struct {
+		name   string
+		kind   PolicyKind
+		policy string
+		want   Statement
+		err    string
+	}{
+		{
+			name:   "single statement object",
+			kind:   IdentityPolicy,
+			policy: `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"sqs:SendMessage","Resource":"arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:jobs"}}`,
+			want:   Statement{Effect: EffectAllow, Action: Value{"sqs:SendMessage"}, Resource: Value{"arn:aws:sqs:us-east-1:123456789012:jobs"}},
+		},
+		{
+			name:   "anonymous principal",
+			kind:   ResourcePolicy,
+			policy: `{"Version":"2012-10-17","Statement":[{"Effect":"Deny","Principal":"*","Action":"s3:*","Resource":"arn:aws:s3:::assets/*","Condition":{"Bool":{"aws:SecureTransport":false}}}]}`,
+			want:   Statement{Effect: EffectDeny, Principal: Principal{"AWS": Value{"*"}}, Action: Value{"s3:*"}, Resource: Value{"arn:aws:s3:::assets/*"}, Condition: Bool("aws:SecureTransport", false)},
+		},
+		{
+			name:   "older policy version",
+			kind:   IdentityPolicy,
+			policy: `{"Version":"2008-10-17","Statement":[{"Effect":"Allow","Action":["s3:GetObject"],"Resource":["arn:aws:s3:::assets/*"]}]}`,
+			want:   Statement{Effect: EffectAllow, Action: Value{"s3:GetObject"}, Resource: Value{"arn:aws:s3:::assets/*"}},
+		},
+		{
+			name:   "unknown policy version",
+			kind:   IdentityPolicy,
+			policy: `{"Version":"2020-01-01","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`,
+			err:    "unknown Version",
+		},
+		{
+			name:   "missing Effect",
+			kind:   IdentityPolicy,
+			policy: `{"Version":"2012-10-17","Statement":{"Action":"s3:GetObject","Resource":"*"}}`,
+			err:    "statement 0 is missing Effect",
+		},
+		{
+			name:   "not JSON",
+			kind:   IdentityPolicy,
+			policy: `Version: 2012-10-17`,
+			err:    "not a JSON object",
+		},
+	}
+
+	for _, tt := range tests {
+		f := NewFactory().WithKind(tt.kind).WithAccountID("123456789012").WithRegion("us-east-1")
+
+		err := f.ImportPolicy(tt.policy)
+		if len(tt.err) > 0 {
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Errorf("%s: got error %v, want %s", tt.name, err, tt.err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+
+		doc, err := f.GetPolicyDocument()
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if len(doc.Statement) != 1 || !reflect.DeepEqual(doc.Statement[0], tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, doc.Statement, tt.want)
+		}
+	}
+}
+
+func TestImportPolicyFile(t *testing.T) {
+	f := NewFactory().WithKind(ResourcePolicy).WithAccountID("123456789012").WithRegion("eu-west-1")
+	if err := f.ImportPolicyFile("testdata/bucket_policy.json"); err != nil {
+		t.Fatal(err)
+	}
+
+	doc, err := f.GetPolicyDocument()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := publicRead
+	want.Resource = Value{"arn:aws:s3:::assets-123456789012/*"}
+	if len(doc.Statement) != 1 || !reflect.DeepEqual(doc.Statement[0], want) {
+		t.Errorf("got %+v, want %+v", doc.Statement, want)
+	}
+
+	if err := NewFactory().ImportPolicyFile("testdata/missing.json"); err == nil {
+		t.Error("got no error for a missing file")
+	}
+}
@@ -0,0 +1,242 @@
+package sampolicies
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+const (
+	// NilInputErr is returned when a template parameter or custom variable of an InputFactory is a nil input
+	NilInputErr = "factory has nil inputs for %s"
+)
+
+// InputFactory is like Factory, but takes Pulumi inputs for the template
+// parameters, the partition, region, and accountID, and the custom
+// variables. This allows you to use the outputs of other resources, like the
+// name of a table or the ARN of a queue, without wrapping every call in an
+// Apply. The templates, conditions, and policies are kept in a Factory, so
+// they are checked the same way, and the inputs are substituted once they
+// have resolved.
+type InputFactory struct {
+	factory   *Factory
+	params    []map[string]pulumi.StringInput
+	variables map[string]pulumi.StringInput
+	partition pulumi.StringInput
+	region    pulumi.StringInput
+	accountID pulumi.StringInput
+}
+
+// NewInputFactory returns a new InputFactory pointer that can be chained with
+// builder methods to set multiple configuration values inline without using
+// pointers.
+func NewInputFactory() *InputFactory {
+	return &InputFactory{factory: NewFactory()}
+}
+
+// WithPartition sets the AWS partition to use and returns a pointer to the
+// existing resource to allow chaining.
+func (f *InputFactory) WithPartition(partition pulumi.StringInput) *InputFactory {
+	f.partition = partition
+	return f
+}
+
+// WithRegion sets the AWS region to use and returns a pointer to the
+// existing resource to allow chaining.
+func (f *InputFactory) WithRegion(region pulumi.StringInput) *InputFactory {
+	f.region = region
+	return f
+}
+
+// WithAccountID sets the AWS AccountID to use and returns a pointer to the
+// existing resource to allow chaining.
+func (f *InputFactory) WithAccountID(accountID pulumi.StringInput) *InputFactory {
+	f.accountID = accountID
+	return f
+}
+
+// WithKind sets the kind of policy the factory builds and returns a pointer
+// to the existing resource to allow chaining, just like Factory.WithKind.
+func (f *InputFactory) WithKind(kind PolicyKind) *InputFactory {
+	f.factory.WithKind(kind)
+	return f
+}
+
+// WithVariable sets a custom variable that is substituted for ${name} in
+// all policies and returns a pointer to the existing resource to allow
+// chaining. Custom variables can't override the parameters of a template.
+func (f *InputFactory) WithVariable(name string, value pulumi.StringInput) *InputFactory {
+	if f.variables == nil {
+		f.variables = make(map[string]pulumi.StringInput)
+	}
+	f.variables[name] = value
+
+	// The factory only needs the name until the value has resolved
+	f.factory.WithVariable(name, "")
+	return f
+}
+
+// WithCondition adds the condition to every Allow statement of the policies
+// in the factory and returns a pointer to the existing resource to allow
+// chaining, just like Factory.WithCondition.
+func (f *InputFactory) WithCondition(condition Condition) *InputFactory {
+	f.factory.WithCondition(condition)
+	return f
+}
+
+// GetPolicyStatement returns the AWS IAM policy statement as an output that
+// resolves once the partition, region, accountID, custom variables, and all
+// template parameters have resolved. The policy is created the same way as
+// Factory.GetPolicyStatement does, and the output fails with the same errors.
+// The output also fails if a template parameter or custom variable is a nil
+// input.
+func (f *InputFactory) GetPolicyStatement() pulumi.StringOutput {
+	inputs := make([]interface{}, 0)
+
+	// index adds the input to the list of inputs to resolve and returns its
+	// position, or -1 if the input isn't set
+	index := func(input pulumi.StringInput) int {
+		if input == nil {
+			return -1
+		}
+		inputs = append(inputs, input)
+		return len(inputs) - 1
+	}
+
+	partition, region, accountID := index(f.partition), index(f.region), index(f.accountID)
+
+	// A nil parameter or variable would silently become an empty string, like
+	// the bucket name in arn:aws:s3:::/*
+	missing := make([]string, 0)
+
+	variables := make(map[string]int, len(f.variables))
+	for name, val := range f.variables {
+		if val == nil {
+			missing = append(missing, fmt.Sprintf("variable %s", name))
+		}
+		variables[name] = index(val)
+	}
+
+	params := make([]map[string]int, len(f.params))
+	for idx, p := range f.params {
+		params[idx] = make(map[string]int, len(p))
+		for name, val := range p {
+			if val == nil {
+				missing = append(missing, fmt.Sprintf("%s of %s", name, f.factory.policies[idx].name))
+			}
+			params[idx][name] = index(val)
+		}
+	}
+	sort.Strings(missing)
+
+	return pulumi.All(inputs...).ApplyString(func(values []interface{}) (string, error) {
+		if len(missing) > 0 {
+			return "", fmt.Errorf(NilInputErr, strings.Join(missing, ", "))
+		}
+
+		value := func(idx int) string {
+			if idx < 0 {
+				return ""
+			}
+			s, _ := values[idx].(string)
+			return s
+		}
+
+		factory := f.factory.clone().WithPartition(value(partition)).WithRegion(value(region)).WithAccountID(value(accountID))
+		for name, idx := range variables {
+			factory.WithVariable(name, value(idx))
+		}
+
+		for idx := range factory.policies {
+			if len(params[idx]) == 0 {
+				continue
+			}
+			resolved := make(map[string]string, len(params[idx]))
+			for name, i := range params[idx] {
+				resolved[name] = value(i)
+			}
+			factory.policies[idx].params = resolved
+		}
+
+		return factory.GetPolicyStatement()
+	})
+}
+
+// Templates returns the templates that can be added to the factory, sorted
+// by name.
+func (f *InputFactory) Templates() []Template {
+	return f.factory.Templates()
+}
+
+// Add adds the template with the given name to the factory. The params map
+// has an input for every parameter of the template, keyed by the name of the
+// parameter (like TableName). An error is returned if the template doesn't
+// exist, or if a parameter is missing or unknown.
+func (f *InputFactory) Add(name string, params map[string]pulumi.StringInput) error {
+	return f.AddWithCondition(name, params, nil)
+}
+
+// AddWithCondition is like Add, but adds the condition to every Allow
+// statement of the template, just like Factory.AddWithCondition.
+func (f *InputFactory) AddWithCondition(name string, params map[string]pulumi.StringInput, condition Condition) error {
+	// The factory checks the names of the parameters, and the values are
+	// substituted once they have resolved
+	names := make(map[string]string, len(params))
+	for key := range params {
+		names[key] = ""
+	}
+
+	if err := f.factory.AddWithCondition(name, names, condition); err != nil {
+		return err
+	}
+	f.track(params)
+	return nil
+}
+
+// RegisterTemplate registers a custom template with the factory, after which
+// it can be added with Add just like the AWS SAM policy templates. The
+// template is checked the same way as Factory.RegisterTemplate does.
+func (f *InputFactory) RegisterTemplate(t Template) error {
+	return f.factory.RegisterTemplate(t)
+}
+
+// AddStatements adds custom statements to the factory. The statements can
+// use the same AWS pseudo parameters and custom variables as the templates.
+func (f *InputFactory) AddStatements(statements ...Statement) {
+	f.factory.AddStatements(statements...)
+	f.track(nil)
+}
+
+// AddGuardrails adds the Deny statements of the guardrails to the factory,
+// just like Factory.AddGuardrails.
+func (f *InputFactory) AddGuardrails(guardrails ...Guardrail) error {
+	if err := f.factory.AddGuardrails(guardrails...); err != nil {
+		return err
+	}
+	f.track(nil)
+	return nil
+}
+
+// ClearPolicies removes all policies so you can begin with a clean slate
+func (f *InputFactory) ClearPolicies() {
+	f.factory.ClearPolicies()
+	f.params = nil
+}
+
+// track records the inputs of the template parameters for the policies that
+// were added to the factory since the last call
+func (f *InputFactory) track(params map[string]pulumi.StringInput) {
+	for len(f.params) < len(f.factory.policies) {
+		f.params = append(f.params, params)
+	}
+}
+
+// mustAdd is like Add but panics if an error is returned. It is used by the
+// generated methods, which always pass the right parameters.
+func (f *InputFactory) mustAdd(name string, params map[string]pulumi.StringInput) {
+	if err := f.Add(name, params); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,168 @@
+package sampolicies
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+// mocks lets the tests resolve outputs without a Pulumi engine
+type mocks int
+
+func (mocks) NewResource(typeToken, name string, inputs resource.PropertyMap, provider, id string) (string, resource.PropertyMap, error) {
+	return name + "_id", inputs, nil
+}
+
+func (mocks) Call(token string, args resource.PropertyMap, provider string) (resource.PropertyMap, error) {
+	return args, nil
+}
+
+// resolvePolicy returns the resolved policy statement of the factory, or the
+// error the output failed with. The test fails if the output doesn't resolve
+// in time.
+func resolvePolicy(t *testing.T, f *InputFactory) (string, error) {
+	type result struct {
+		policy string
+		err    error
+	}
+	done := make(chan result, 1)
+
+	go func() {
+		var policy string
+		err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+			out := f.GetPolicyStatement().ApplyString(func(s string) string {
+				policy = s
+				return s
+			})
+			// Exporting the output makes RunErr wait for it and return its error
+			ctx.Export("policy", out)
+			return nil
+		}, pulumi.WithMocks("project", "stack", mocks(0)))
+		done <- result{policy, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.policy, r.err
+	case <-time.After(10 * time.Second):
+		t.Fatal("the policy statement didn't resolve")
+	}
+	return "", nil
+}
+
+func TestInputFactoryMatchesFactory(t *testing.T) {
+	statement := Statement{
+		Effect:   EffectAllow,
+		Action:   Value{"ssm:GetParameter"},
+		Resource: Value{"arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter/${Stage}/*"},
+	}
+	guardrail := DenyInsecureTransport()
+
+	f := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1").WithVariable("Stage", "dev")
+	f.AddDynamoDBReadPolicy("orders")
+	f.AddStatements(statement)
+	f.AddSQSSendMessagePolicy("jobs")
+	if err := f.AddGuardrails(guardrail); err != nil {
+		t.Fatal(err)
+	}
+	want, err := f.GetPolicyStatement()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inputs := NewInputFactory().
+		WithAccountID(pulumi.String("123456789012")).
+		WithRegion(pulumi.String("us-east-1")).
+		WithVariable("Stage", pulumi.String("dev"))
+	inputs.AddDynamoDBReadPolicy(pulumi.String("orders"))
+	inputs.AddStatements(statement)
+	inputs.AddSQSSendMessagePolicy(pulumi.String("jobs"))
+	if err := inputs.AddGuardrails(guardrail); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := resolvePolicy(t, inputs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Errorf("got policy\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestInputFactoryAddChecksParameters(t *testing.T) {
+	f := NewInputFactory()
+
+	if err := f.Add("DynamoDBReadPolicy", map[string]pulumi.StringInput{"Table": pulumi.String("orders")}); err == nil {
+		t.Error("expected an error for an unknown parameter")
+	}
+	if err := f.Add("NoSuchPolicy", nil); err == nil {
+		t.Error("expected an error for an unknown template")
+	}
+	if len(f.params) != 0 || len(f.factory.policies) != 0 {
+		t.Errorf("failed calls added %d policies", len(f.factory.policies))
+	}
+
+	if err := f.Add("DynamoDBReadPolicy", map[string]pulumi.StringInput{"TableName": pulumi.String("orders")}); err != nil {
+		t.Fatal(err)
+	}
+	f.AddStatements(Statement{Effect: EffectAllow, Action: Value{"s3:ListAllMyBuckets"}, Resource: Value{"*"}})
+	if len(f.params) != len(f.factory.policies) {
+		t.Fatalf("got %d parameter sets for %d policies", len(f.params), len(f.factory.policies))
+	}
+
+	policy, err := resolvePolicy(t, f.WithAccountID(pulumi.String("123456789012")).WithRegion(pulumi.String("us-east-1")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(policy, "table/orders") {
+		t.Errorf("policy doesn't use the table name: %s", policy)
+	}
+}
+
+func TestInputFactoryNilInputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		factory func() *InputFactory
+		missing string
+	}{
+		{
+			name: "template parameter",
+			factory: func() *InputFactory {
+				f := NewInputFactory()
+				f.AddS3ReadPolicy(nil)
+				return f
+			},
+			missing: "BucketName of S3ReadPolicy",
+		},
+		{
+			name: "custom variable",
+			factory: func() *InputFactory {
+				f := NewInputFactory().WithVariable("Stage", nil)
+				f.AddS3ReadPolicy(pulumi.String("uploads"))
+				return f
+			},
+			missing: "variable Stage",
+		},
+	}
+
+	for _, tt := range tests {
+		f := tt.factory().WithAccountID(pulumi.String("123456789012")).WithRegion(pulumi.String("us-east-1"))
+		policy, err := resolvePolicy(t, f)
+		if err == nil || !strings.Contains(err.Error(), tt.missing) {
+			t.Errorf("%s: got policy %q and error %v, want an error naming %s", tt.name, policy, err, tt.missing)
+		}
+	}
+}
+
+func TestInputFactoryFailedPolicy(t *testing.T) {
+	f := NewInputFactory().WithRegion(pulumi.String("us-east-1"))
+	f.AddS3ReadPolicy(pulumi.String("uploads"))
+
+	if _, err := resolvePolicy(t, f); err == nil || err.Error() != AccountIDMissingErr {
+		t.Errorf("got error %v, want %s", err, AccountIDMissingErr)
+	}
+}
@@ -0,0 +1,111 @@
+package sampolicies
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const (
+	// PolicyKindErr is returned when a factory is used for a different kind of policy than it builds
+	PolicyKindErr = "factory builds %s policies, not %s policies"
+)
+
+// PolicyKind is the kind of policy a Factory builds. The kind determines
+// which elements the statements can have.
+type PolicyKind string
+
+const (
+	// IdentityPolicy is a policy attached to a user, group, or role. Its
+	// statements can't have a Principal or NotPrincipal. This is the default.
+	IdentityPolicy PolicyKind = "identity"
+
+	// ResourcePolicy is a policy attached to a resource, like a bucket policy
+	// or a queue policy. Its statements must have a Principal or NotPrincipal.
+	ResourcePolicy PolicyKind = "resource-based"
+
+	// TrustPolicy is the policy of a role that defines who can assume it. Its
+	// statements must have a Principal and an sts action, and can't have a
+	// NotPrincipal, Resource, or NotResource.
+	TrustPolicy PolicyKind = "trust"
+)
+
+// WithKind sets the kind of policy the factory builds and returns a pointer
+// to the existing resource to allow chaining. The policies are validated
+// against the kind, so a trust or resource-based statement can't end up in
+// an identity policy.
+func (f *Factory) WithKind(kind PolicyKind) *Factory {
+	f.kind = kind
+	return f
+}
+
+// MaxSize returns the maximum number of characters of a policy of the kind:
+// InlinePolicyMaxSize for an identity policy, TrustPolicyMaxSize for a trust
+// policy, and BucketPolicyMaxSize for a resource-based policy, as bucket
+// policies are the most common resource-based policies.
+func (k PolicyKind) MaxSize() int {
+	switch k {
+	case TrustPolicy:
+		return TrustPolicyMaxSize
+	case ResourcePolicy:
+		return BucketPolicyMaxSize
+	}
+	return InlinePolicyMaxSize
+}
+
+// Kind returns the kind of policy the factory builds
+func (f *Factory) Kind() PolicyKind {
+	if len(f.kind) == 0 {
+		return IdentityPolicy
+	}
+	return f.kind
+}
+
+// ValidatePolicyDocumentKind is like ValidatePolicyDocument, but also checks
+// that the statements only have the elements allowed in the kind of policy.
+func ValidatePolicyDocumentKind(policy string, kind PolicyKind) error {
+	if err := validateDocument([]byte(policy), kind); err != nil {
+		return fmt.Errorf(InvalidDocumentErr, err.Error())
+	}
+	return nil
+}
+
+// checkKind makes sure the statement only has the elements allowed in the
+// kind of policy. An empty kind allows all elements.
+func checkKind(statement map[string]json.RawMessage, kind PolicyKind) error {
+	_, hasPrincipal := statement["Principal"]
+	_, hasNotPrincipal := statement["NotPrincipal"]
+
+	switch kind {
+	case IdentityPolicy:
+		for _, key := range []string{"Principal", "NotPrincipal"} {
+			if _, ok := statement[key]; ok {
+				return fmt.Errorf("has %s, which isn't allowed in an %s policy", key, kind)
+			}
+		}
+	case ResourcePolicy:
+		if !hasPrincipal && !hasNotPrincipal {
+			return fmt.Errorf("is missing Principal or NotPrincipal, which a %s policy requires", kind)
+		}
+	case TrustPolicy:
+		for _, key := range []string{"NotPrincipal", "Resource", "NotResource", "NotAction"} {
+			if _, ok := statement[key]; ok {
+				return fmt.Errorf("has %s, which isn't allowed in a %s policy", key, kind)
+			}
+		}
+		if !hasPrincipal {
+			return fmt.Errorf("is missing Principal, which a %s policy requires", kind)
+		}
+
+		var actions Value
+		if err := json.Unmarshal(statement["Action"], &actions); err != nil {
+			return fmt.Errorf("has invalid Action: %s", err.Error())
+		}
+		for _, action := range actions {
+			if !strings.HasPrefix(strings.ToLower(action), "sts:") {
+				return fmt.Errorf("has Action %q, which isn't allowed in a %s policy", action, kind)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package sampolicies
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPolicyKindMaxSize(t *testing.T) {
+	tests := []struct {
+		kind PolicyKind
+		size int
+	}{
+		{"", InlinePolicyMaxSize},
+		{IdentityPolicy, InlinePolicyMaxSize},
+		{TrustPolicy, TrustPolicyMaxSize},
+		{ResourcePolicy, BucketPolicyMaxSize},
+	}
+
+	for _, tt := range tests {
+		if got := NewFactory().WithKind(tt.kind).Kind().MaxSize(); got != tt.size {
+			t.Errorf("%q: got %d, want %d", tt.kind, got, tt.size)
+		}
+	}
+}
+
+func TestCheckPolicySizeOfKind(t *testing.T) {
+	// A trust policy for 40 accounts is larger than the trust policy limit,
+	// but much smaller than the inline role policy limit
+	f := sizedFactory(TrustPolicy, 40)
+
+	err := f.CheckPolicySize(0)
+	if err == nil || !strings.Contains(err.Error(), "trust policy limit") {
+		t.Errorf("got %v, want an error naming the trust policy limit", err)
+	}
+	if err := f.CheckPolicySize(InlinePolicyMaxSize); err != nil {
+		t.Errorf("got %v for the inline role policy limit", err)
+	}
+}
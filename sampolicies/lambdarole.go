@@ -0,0 +1,177 @@
+package sampolicies
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/pulumi/pulumi-aws/sdk/v2/go/aws/iam"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+const (
+	// lambdaRoleType is the type token of the LambdaRole component resource
+	lambdaRoleType = "pulumi-helpers:sampolicies:LambdaRole"
+
+	// basicExecutionRoleArn is the ARN of the managed policy that allows a Lambda function to write logs
+	basicExecutionRoleArn = "arn:%s:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+)
+
+const (
+	// RoleManagedPoliciesMax is the default maximum number of managed policies that can be attached to a role
+	RoleManagedPoliciesMax = 10
+
+	// TooManyManagedPoliciesErr is returned when a role would get more managed policies than can be attached to it
+	TooManyManagedPoliciesErr = "role %s would have %d managed policies, which exceeds the limit of %d"
+)
+
+// LambdaRole is a Pulumi component resource that creates an IAM role AWS
+// Lambda can assume, with the policy created by a Factory and the
+// AWSLambdaBasicExecutionRole managed policy attached to it.
+type LambdaRole struct {
+	pulumi.ResourceState
+
+	// Role is the IAM role that is created
+	Role *iam.Role
+
+	// Arn is the ARN of the IAM role
+	Arn pulumi.StringOutput `pulumi:"arn"`
+
+	// Name is the name of the IAM role
+	Name pulumi.StringOutput `pulumi:"name"`
+}
+
+// LambdaRoleArgs are the optional arguments of a LambdaRole.
+type LambdaRoleArgs struct {
+	// ManagedPolicy creates the policy of the factory as managed policies
+	// attached to the role, rather than an inline policy. The statements are
+	// split over multiple managed policies if they don't fit in one.
+	ManagedPolicy bool
+
+	// PolicyArns are the ARNs of other managed policies to attach to the role
+	PolicyArns []string
+}
+
+// NewLambdaRole creates an IAM role that AWS Lambda can assume, together with
+// the policy created by the factory and the attachment of the
+// AWSLambdaBasicExecutionRole managed policy, as children of the component.
+// The args can be nil to create the policy inline. An error is returned if
+// the factory doesn't build an IdentityPolicy, can't create the policy, or
+// if the role would get more than RoleManagedPoliciesMax managed policies,
+// counting AWSLambdaBasicExecutionRole, the PolicyArns, and the managed
+// policies the statements are split over.
+func NewLambdaRole(ctx *pulumi.Context, name string, factory *Factory, args *LambdaRoleArgs, opts ...pulumi.ResourceOption) (*LambdaRole, error) {
+	if args == nil {
+		args = &LambdaRoleArgs{}
+	}
+
+	if len(factory.Partition()) == 0 {
+		return nil, errors.New(PartitionMissingErr)
+	}
+
+	if factory.Kind() != IdentityPolicy {
+		return nil, fmt.Errorf(PolicyKindErr, factory.Kind(), IdentityPolicy)
+	}
+
+	// Create the policies before any resources, so an invalid factory doesn't
+	// leave a role behind. Managed policies are split when they're too large.
+	policies := make([]string, 0)
+	if len(factory.policies) > 0 {
+		if args.ManagedPolicy {
+			var err error
+			policies, err = factory.GetPolicyStatements(ManagedPolicyMaxSize)
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			policy, err := factory.GetPolicyStatement()
+			if err != nil {
+				return nil, err
+			}
+			policies = append(policies, policy)
+		}
+	}
+
+	managed := 1 + len(args.PolicyArns)
+	if args.ManagedPolicy {
+		managed += len(policies)
+	}
+	if managed > RoleManagedPoliciesMax {
+		return nil, fmt.Errorf(TooManyManagedPoliciesErr, name, managed, RoleManagedPoliciesMax)
+	}
+
+	component := &LambdaRole{}
+	if err := ctx.RegisterComponentResource(lambdaRoleType, name, component, opts...); err != nil {
+		return nil, err
+	}
+
+	parent := pulumi.Parent(component)
+
+	role, err := iam.NewRole(ctx, name, &iam.RoleArgs{
+		AssumeRolePolicy: pulumi.String(AssumeRoleLambda()),
+	}, parent)
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, policy := range policies {
+		policyName := fmt.Sprintf("%s-policy", name)
+		if idx > 0 {
+			policyName = fmt.Sprintf("%s-policy-%d", name, idx)
+		}
+
+		if !args.ManagedPolicy {
+			_, err = iam.NewRolePolicy(ctx, policyName, &iam.RolePolicyArgs{
+				Role:   role.Name,
+				Policy: pulumi.String(policy),
+			}, parent)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		managed, err := iam.NewPolicy(ctx, policyName, &iam.PolicyArgs{
+			Policy: pulumi.String(policy),
+		}, parent)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = iam.NewRolePolicyAttachment(ctx, policyName, &iam.RolePolicyAttachmentArgs{
+			Role:      role.Name,
+			PolicyArn: managed.Arn,
+		}, parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	arns := append([]string{fmt.Sprintf(basicExecutionRoleArn, factory.Partition())}, args.PolicyArns...)
+	for idx, arn := range arns {
+		attachmentName := fmt.Sprintf("%s-basic-execution", name)
+		if idx > 0 {
+			attachmentName = fmt.Sprintf("%s-attachment-%d", name, idx)
+		}
+
+		_, err = iam.NewRolePolicyAttachment(ctx, attachmentName, &iam.RolePolicyAttachmentArgs{
+			Role:      role.Name,
+			PolicyArn: pulumi.String(arn),
+		}, parent)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	component.Role = role
+	component.Arn = role.Arn
+	component.Name = role.Name
+
+	if err := ctx.RegisterResourceOutputs(component, pulumi.Map{
+		"arn":  role.Arn,
+		"name": role.Name,
+	}); err != nil {
+		return nil, err
+	}
+
+	return component, nil
+}
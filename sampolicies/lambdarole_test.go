@@ -0,0 +1,178 @@
+package sampolicies
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/pulumi/pulumi/sdk/v2/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+)
+
+// recordingMocks records the resources a program creates
+type recordingMocks struct {
+	mu        sync.Mutex
+	resources map[string]resource.PropertyMap
+}
+
+func (m *recordingMocks) NewResource(typeToken, name string, inputs resource.PropertyMap, provider, id string) (string, resource.PropertyMap, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.resources[typeToken+"::"+name] = inputs
+
+	outputs := inputs.Copy()
+	outputs["name"] = resource.NewStringProperty(name)
+	outputs["arn"] = resource.NewStringProperty("arn:aws:iam::123456789012:" + name)
+	return name + "_id", outputs, nil
+}
+
+func (m *recordingMocks) Call(token string, args resource.PropertyMap, provider string) (resource.PropertyMap, error) {
+	return args, nil
+}
+
+// names returns the sorted type tokens and names of the recorded resources
+func (m *recordingMocks) names() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]string, 0, len(m.resources))
+	for key := range m.resources {
+		out = append(out, key)
+	}
+	sort.Strings(out)
+	return out
+}
+
+// input returns the string input of a recorded resource
+func (m *recordingMocks) input(key string, property string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	val, ok := m.resources[key][resource.PropertyKey(property)]
+	if !ok || !val.IsString() {
+		return ""
+	}
+	return val.StringValue()
+}
+
+// newLambdaRole runs NewLambdaRole with mocks and returns the mocks and the
+// error of NewLambdaRole
+func newLambdaRole(t *testing.T, factory *Factory, args *LambdaRoleArgs) (*recordingMocks, error) {
+	m := &recordingMocks{resources: make(map[string]resource.PropertyMap)}
+
+	var roleErr error
+	err := pulumi.RunErr(func(ctx *pulumi.Context) error {
+		_, roleErr = NewLambdaRole(ctx, "orders", factory, args)
+		return nil
+	}, pulumi.WithMocks("project", "stack", m))
+	if err != nil {
+		t.Fatalf("running the program failed: %s", err)
+	}
+	return m, roleErr
+}
+
+func ordersFactory() *Factory {
+	f := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1")
+	f.AddDynamoDBReadPolicy("orders")
+	return f
+}
+
+func TestLambdaRoleInlinePolicy(t *testing.T) {
+	m, err := newLambdaRole(t, ordersFactory(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"aws:iam/role:Role::orders",
+		"aws:iam/rolePolicy:RolePolicy::orders-policy",
+		"aws:iam/rolePolicyAttachment:RolePolicyAttachment::orders-basic-execution",
+		"pulumi-helpers:sampolicies:LambdaRole::orders",
+	}
+	if got := m.names(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("got resources\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if got := m.input("aws:iam/role:Role::orders", "assumeRolePolicy"); got != AssumeRoleLambda() {
+		t.Errorf("got trust policy %s, want %s", got, AssumeRoleLambda())
+	}
+
+	policy, _ := ordersFactory().GetPolicyStatement()
+	if got := m.input("aws:iam/rolePolicy:RolePolicy::orders-policy", "policy"); got != policy {
+		t.Errorf("got inline policy %s, want %s", got, policy)
+	}
+	if got := m.input("aws:iam/rolePolicy:RolePolicy::orders-policy", "role"); got != "orders" {
+		t.Errorf("got inline policy on role %q, want orders", got)
+	}
+
+	basic := "arn:aws:iam::aws:policy/service-role/AWSLambdaBasicExecutionRole"
+	if got := m.input("aws:iam/rolePolicyAttachment:RolePolicyAttachment::orders-basic-execution", "policyArn"); got != basic {
+		t.Errorf("got basic execution policy %s, want %s", got, basic)
+	}
+}
+
+func TestLambdaRoleManagedPolicies(t *testing.T) {
+	m, err := newLambdaRole(t, sizedFactory(IdentityPolicy, 100), &LambdaRoleArgs{
+		ManagedPolicy: true,
+		PolicyArns:    []string{"arn:aws:iam::aws:policy/AWSXrayWriteOnlyAccess"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policies, err := sizedFactory(IdentityPolicy, 100).GetPolicyStatements(ManagedPolicyMaxSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(policies) < 2 {
+		t.Fatalf("expected the fixture to need multiple managed policies, got %d", len(policies))
+	}
+
+	for idx, policy := range policies {
+		name := "orders-policy"
+		if idx > 0 {
+			name = fmt.Sprintf("orders-policy-%d", idx)
+		}
+		if got := m.input("aws:iam/policy:Policy::"+name, "policy"); got != policy {
+			t.Errorf("policy %s: got %s, want %s", name, got, policy)
+		}
+		if got := m.input("aws:iam/rolePolicyAttachment:RolePolicyAttachment::"+name, "policyArn"); got != "arn:aws:iam::123456789012:"+name {
+			t.Errorf("attachment %s: got policy %s", name, got)
+		}
+	}
+
+	if got := m.input("aws:iam/rolePolicyAttachment:RolePolicyAttachment::orders-attachment-1", "policyArn"); got != "arn:aws:iam::aws:policy/AWSXrayWriteOnlyAccess" {
+		t.Errorf("got extra attachment %q", got)
+	}
+	for _, name := range m.names() {
+		if strings.HasPrefix(name, "aws:iam/rolePolicy:RolePolicy::") {
+			t.Errorf("unexpected inline policy %s", name)
+		}
+	}
+}
+
+func TestLambdaRoleTooManyManagedPolicies(t *testing.T) {
+	arns := make([]string, RoleManagedPoliciesMax)
+	for idx := range arns {
+		arns[idx] = fmt.Sprintf("arn:aws:iam::123456789012:policy/extra-%d", idx)
+	}
+
+	tests := []struct {
+		name    string
+		factory *Factory
+		args    *LambdaRoleArgs
+	}{
+		{"too many policy arns", ordersFactory(), &LambdaRoleArgs{PolicyArns: arns}},
+		{"too many split policies", sizedFactory(IdentityPolicy, 600), &LambdaRoleArgs{ManagedPolicy: true}},
+	}
+
+	for _, tt := range tests {
+		m, err := newLambdaRole(t, tt.factory, tt.args)
+		if err == nil || !strings.Contains(err.Error(), "exceeds the limit of 10") {
+			t.Errorf("%s: got error %v", tt.name, err)
+		}
+		if names := m.names(); len(names) > 0 {
+			t.Errorf("%s: created resources %v", tt.name, names)
+		}
+	}
+}
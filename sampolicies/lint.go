@@ -0,0 +1,364 @@
+package sampolicies
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity is how risky a finding of the linter is.
+type Severity int
+
+const (
+	// SeverityLow is a finding that is worth reviewing, but often intended
+	SeverityLow Severity = iota + 1
+
+	// SeverityMedium is a finding that grants more than most workloads need
+	SeverityMedium
+
+	// SeverityHigh is a finding that can give a principal full control over
+	// the account or its data
+	SeverityHigh
+)
+
+// String returns the name of the severity
+func (s Severity) String() string {
+	switch s {
+	case SeverityLow:
+		return "low"
+	case SeverityMedium:
+		return "medium"
+	case SeverityHigh:
+		return "high"
+	}
+	return fmt.Sprintf("severity(%d)", int(s))
+}
+
+// The rules of the linter.
+const (
+	// RuleWildcardAction flags Allow statements with a wildcard in an
+	// action, or with NotAction
+	RuleWildcardAction = "wildcard-action"
+
+	// RuleWildcardResource flags Allow statements on all resources, on all
+	// resources of a service or resource type, or with NotResource
+	RuleWildcardResource = "wildcard-resource"
+
+	// RulePassRoleWithoutCondition flags Allow statements that grant
+	// iam:PassRole without an iam:PassedToService condition
+	RulePassRoleWithoutCondition = "passrole-without-condition"
+
+	// RulePrivilegeEscalation flags policies that grant a combination of
+	// actions known to allow a principal to escalate its privileges
+	RulePrivilegeEscalation = "privilege-escalation"
+)
+
+// Finding is a single issue the linter found in a policy document.
+type Finding struct {
+	// Rule is the rule that was violated, like RuleWildcardResource
+	Rule string
+
+	// Severity is how risky the finding is
+	Severity Severity
+
+	// Statement is the index of the statement in the policy document, or -1
+	// for findings that span multiple statements
+	Statement int
+
+	// Sid is the Sid of the statement, if it has one
+	Sid string
+
+	// Message describes the finding
+	Message string
+}
+
+// String returns the finding as a single line
+func (f Finding) String() string {
+	location := "policy"
+	if f.Statement >= 0 {
+		location = fmt.Sprintf("statement %d", f.Statement)
+		if len(f.Sid) > 0 {
+			location = fmt.Sprintf("%s (%s)", location, f.Sid)
+		}
+	}
+	return fmt.Sprintf("[%s] %s: %s: %s", f.Severity, f.Rule, location, f.Message)
+}
+
+// LintReport is the list of findings of the linter, sorted from the highest
+// to the lowest severity.
+type LintReport struct {
+	Findings []Finding
+}
+
+// HasSeverity returns true if the report has a finding with at least the
+// given severity, which allows a pipeline to fail on risky policies.
+func (r LintReport) HasSeverity(severity Severity) bool {
+	for _, f := range r.Findings {
+		if f.Severity >= severity {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the findings with one finding per line
+func (r LintReport) String() string {
+	lines := make([]string, len(r.Findings))
+	for idx, f := range r.Findings {
+		lines[idx] = f.String()
+	}
+	return strings.Join(lines, "\n")
+}
+
+// escalation is a combination of actions that together allow a principal to
+// escalate its privileges
+type escalation struct {
+	actions     []string
+	description string
+}
+
+// escalations are the known combinations of actions that allow privilege
+// escalation
+var escalations = []escalation{
+	{[]string{"iam:CreatePolicyVersion"}, "can replace the permissions of a managed policy"},
+	{[]string{"iam:SetDefaultPolicyVersion"}, "can activate an older version of a managed policy"},
+	{[]string{"iam:AttachUserPolicy"}, "can attach any managed policy to a user"},
+	{[]string{"iam:AttachGroupPolicy"}, "can attach any managed policy to a group"},
+	{[]string{"iam:AttachRolePolicy"}, "can attach any managed policy to a role"},
+	{[]string{"iam:PutUserPolicy"}, "can add any inline policy to a user"},
+	{[]string{"iam:PutGroupPolicy"}, "can add any inline policy to a group"},
+	{[]string{"iam:PutRolePolicy"}, "can add any inline policy to a role"},
+	{[]string{"iam:AddUserToGroup"}, "can add a user to a more privileged group"},
+	{[]string{"iam:CreateAccessKey"}, "can create access keys for other users"},
+	{[]string{"iam:CreateLoginProfile"}, "can set a console password for other users"},
+	{[]string{"iam:UpdateLoginProfile"}, "can change the console password of other users"},
+	{[]string{"iam:UpdateAssumeRolePolicy", "sts:AssumeRole"}, "can change who can assume a role and assume it"},
+	{[]string{"iam:PassRole", "lambda:CreateFunction", "lambda:InvokeFunction"}, "can run code with the permissions of another role"},
+	{[]string{"iam:PassRole", "lambda:CreateFunction", "lambda:CreateEventSourceMapping"}, "can run code with the permissions of another role"},
+	{[]string{"iam:PassRole", "ec2:RunInstances"}, "can start an instance with the permissions of another role"},
+	{[]string{"iam:PassRole", "cloudformation:CreateStack"}, "can create resources with the permissions of another role"},
+	{[]string{"iam:PassRole", "glue:CreateDevEndpoint"}, "can run code with the permissions of another role"},
+	{[]string{"iam:PassRole", "datapipeline:CreatePipeline", "datapipeline:PutPipelineDefinition"}, "can run commands with the permissions of another role"},
+	{[]string{"lambda:UpdateFunctionCode"}, "can change the code of a function that runs with another role"},
+	{[]string{"glue:UpdateDevEndpoint"}, "can access a development endpoint that runs with another role"},
+}
+
+// Lint checks the policy document for permissions that aren't least
+// privilege: wildcard actions and resources, iam:PassRole without an
+// iam:PassedToService condition, and combinations of actions that allow
+// privilege escalation. Statements with one of the suppressed Sids are
+// skipped, and don't count towards a privilege escalation.
+func (d *PolicyDocument) Lint(suppressedSids ...string) LintReport {
+	suppressed := make(map[string]bool, len(suppressedSids))
+	for _, sid := range suppressedSids {
+		suppressed[sid] = true
+	}
+
+	findings := make([]Finding, 0)
+	statements := make([]Statement, 0, len(d.Statement))
+
+	for idx, s := range d.Statement {
+		if len(s.Sid) > 0 && suppressed[s.Sid] {
+			continue
+		}
+		statements = append(statements, s)
+
+		if s.Effect != EffectAllow {
+			continue
+		}
+
+		finding := func(rule string, severity Severity, format string, args ...interface{}) {
+			findings = append(findings, Finding{
+				Rule:      rule,
+				Severity:  severity,
+				Statement: idx,
+				Sid:       s.Sid,
+				Message:   fmt.Sprintf(format, args...),
+			})
+		}
+
+		if len(s.NotAction) > 0 {
+			finding(RuleWildcardAction, SeverityHigh, "allows all actions except %s", strings.Join(s.NotAction, ", "))
+		}
+		for _, action := range s.Action {
+			switch {
+			case action == "*":
+				finding(RuleWildcardAction, SeverityHigh, "allows all actions")
+			case strings.HasSuffix(action, ":*"):
+				finding(RuleWildcardAction, SeverityMedium, "allows all actions of %s", strings.TrimSuffix(action, ":*"))
+			case strings.ContainsAny(action, "*?"):
+				finding(RuleWildcardAction, SeverityLow, "allows all actions that match %s", action)
+			}
+		}
+
+		if len(s.NotResource) > 0 {
+			finding(RuleWildcardResource, SeverityMedium, "allows all resources except %s", strings.Join(s.NotResource, ", "))
+		}
+		for _, resource := range s.Resource {
+			switch {
+			case resource == "*":
+				finding(RuleWildcardResource, SeverityMedium, "allows %s on all resources", strings.Join(statementActions(s), ", "))
+			case allowsAllResources(resource):
+				finding(RuleWildcardResource, SeverityMedium, "allows %s on all resources that match %s", strings.Join(statementActions(s), ", "), resource)
+			}
+		}
+
+		if s.grants("iam:PassRole") && !s.hasConditionKey("iam:PassedToService") {
+			severity := SeverityMedium
+			if s.allowsAllResources() {
+				severity = SeverityHigh
+			}
+			finding(RulePassRoleWithoutCondition, severity, "allows iam:PassRole without an iam:PassedToService condition")
+		}
+	}
+
+	for _, e := range escalations {
+		if grantsAll(statements, e.actions) {
+			findings = append(findings, Finding{
+				Rule:      RulePrivilegeEscalation,
+				Severity:  SeverityHigh,
+				Statement: -1,
+				Message:   fmt.Sprintf("%s %s", strings.Join(e.actions, " + "), e.description),
+			})
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		return findings[i].Severity > findings[j].Severity
+	})
+	return LintReport{Findings: findings}
+}
+
+// Lint creates the policy document and checks it like PolicyDocument.Lint.
+func (f *Factory) Lint(suppressedSids ...string) (LintReport, error) {
+	doc, err := f.GetPolicyDocument()
+	if err != nil {
+		return LintReport{}, err
+	}
+	return doc.Lint(suppressedSids...), nil
+}
+
+// LintPolicy parses the policy document and checks it like
+// PolicyDocument.Lint.
+func LintPolicy(policy string, suppressedSids ...string) (LintReport, error) {
+	doc, err := ParsePolicyDocument(policy)
+	if err != nil {
+		return LintReport{}, err
+	}
+	return doc.Lint(suppressedSids...), nil
+}
+
+// statementActions returns the actions of the statement, or a description of
+// the NotAction element
+func statementActions(s Statement) []string {
+	if len(s.NotAction) > 0 {
+		return []string{fmt.Sprintf("all actions except %s", strings.Join(s.NotAction, ", "))}
+	}
+	return s.Action
+}
+
+// grants returns true if the Action or NotAction element of the statement
+// covers the action
+func (s Statement) grants(action string) bool {
+	if len(s.NotAction) > 0 {
+		for _, pattern := range s.NotAction {
+			if matchWildcard(pattern, action) {
+				return false
+			}
+		}
+		return true
+	}
+
+	for _, pattern := range s.Action {
+		if matchWildcard(pattern, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsAllResources returns true if one of the resources of the statement
+// covers all resources of a service, like allowsAllResources does
+func (s Statement) allowsAllResources() bool {
+	for _, resource := range s.Resource {
+		if allowsAllResources(resource) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsAllResources returns true if the resource is *, or an ARN whose
+// resource part is only wildcards, optionally after the resource type, like
+// arn:aws:s3:::* or arn:aws:dynamodb:*:*:table/*. The resource types come
+// from the action catalog, so arn:aws:s3:::my-bucket/* isn't flagged.
+func allowsAllResources(resource string) bool {
+	if resource == "*" {
+		return true
+	}
+
+	parts := strings.SplitN(resource, ":", 6)
+	if len(parts) < 6 || parts[0] != "arn" {
+		return false
+	}
+
+	segments := strings.FieldsFunc(parts[5], func(r rune) bool { return r == '/' || r == ':' })
+	if len(segments) > 1 && isCatalogResourceType(parts[2], segments[0]) {
+		segments = segments[1:]
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	for _, segment := range segments {
+		if len(strings.Trim(segment, "*")) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// hasConditionKey returns true if the statement has a condition on the key
+func (s Statement) hasConditionKey(key string) bool {
+	for _, keys := range s.Condition {
+		for name := range keys {
+			if strings.EqualFold(name, key) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// grantsAll returns true if every action is allowed by one of the statements
+// and isn't denied on all resources by a Deny statement without conditions
+func grantsAll(statements []Statement, actions []string) bool {
+	for _, action := range actions {
+		allowed := false
+		for _, s := range statements {
+			if !s.grants(action) {
+				continue
+			}
+			if s.Effect == EffectDeny && len(s.Condition) == 0 && len(s.NotResource) == 0 && s.Resource.contains("*") {
+				allowed = false
+				break
+			}
+			if s.Effect == EffectAllow {
+				allowed = true
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// contains returns true if the value has the string
+func (v Value) contains(str string) bool {
+	for _, val := range v {
+		if val == str {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,220 @@
+package sampolicies
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// findingKeys returns the rule, severity, and statement of the findings of
+// the rule, or of all findings if the rule is empty
+func findingKeys(report LintReport, rule string) []string {
+	keys := make([]string, 0, len(report.Findings))
+	for _, f := range report.Findings {
+		if len(rule) == 0 || f.Rule == rule {
+			keys = append(keys, fmt.Sprintf("%s/%s/%d", f.Rule, f.Severity, f.Statement))
+		}
+	}
+	return keys
+}
+
+func TestLint(t *testing.T) {
+	allow := func(actions Value, resources Value) Statement {
+		return Statement{Effect: EffectAllow, Action: actions, Resource: resources}
+	}
+	table := Value{"arn:aws:dynamodb:us-east-1:123456789012:table/orders"}
+
+	tests := []struct {
+		name       string
+		statements []Statement
+		suppressed []string
+		rule       string
+		findings   []string
+	}{
+		{
+			name:       "least privilege",
+			statements: []Statement{allow(Value{"dynamodb:GetItem"}, table)},
+			findings:   []string{},
+		},
+		{
+			name:       "all actions",
+			statements: []Statement{allow(Value{"*"}, table)},
+			rule:       RuleWildcardAction,
+			findings:   []string{"wildcard-action/high/0"},
+		},
+		{
+			name:       "all actions of a service",
+			statements: []Statement{allow(Value{"dynamodb:*"}, table)},
+			findings:   []string{"wildcard-action/medium/0"},
+		},
+		{
+			name:       "actions that match a pattern",
+			statements: []Statement{allow(Value{"dynamodb:Get*"}, table)},
+			findings:   []string{"wildcard-action/low/0"},
+		},
+		{
+			name:       "not action",
+			statements: []Statement{{Effect: EffectAllow, NotAction: Value{"iam:*"}, Resource: table}},
+			rule:       RuleWildcardAction,
+			findings:   []string{"wildcard-action/high/0"},
+		},
+		{
+			name:       "all resources",
+			statements: []Statement{allow(Value{"dynamodb:GetItem"}, Value{"*"})},
+			findings:   []string{"wildcard-resource/medium/0"},
+		},
+		{
+			name:       "all buckets",
+			statements: []Statement{allow(Value{"s3:GetObject"}, Value{"arn:aws:s3:::*"})},
+			findings:   []string{"wildcard-resource/medium/0"},
+		},
+		{
+			name:       "all tables",
+			statements: []Statement{allow(Value{"dynamodb:GetItem"}, Value{"arn:aws:dynamodb:*:*:table/*"})},
+			findings:   []string{"wildcard-resource/medium/0"},
+		},
+		{
+			name:       "all functions",
+			statements: []Statement{allow(Value{"lambda:InvokeFunction"}, Value{"arn:aws:lambda:us-east-1:123456789012:function:*"})},
+			findings:   []string{"wildcard-resource/medium/0"},
+		},
+		{
+			name: "objects of a single bucket and a table in any region",
+			statements: []Statement{
+				allow(Value{"s3:GetObject"}, Value{"arn:aws:s3:::my-bucket/*"}),
+				allow(Value{"dynamodb:GetItem"}, Value{"arn:aws:dynamodb:*:*:table/orders"}),
+			},
+			findings: []string{},
+		},
+		{
+			name:       "not resource",
+			statements: []Statement{{Effect: EffectAllow, Action: Value{"dynamodb:GetItem"}, NotResource: table}},
+			findings:   []string{"wildcard-resource/medium/0"},
+		},
+		{
+			name:       "pass role on a single role",
+			statements: []Statement{allow(Value{"iam:PassRole"}, Value{"arn:aws:iam::123456789012:role/worker"})},
+			findings:   []string{"passrole-without-condition/medium/0"},
+		},
+		{
+			name:       "pass role on all roles",
+			statements: []Statement{allow(Value{"iam:PassRole"}, Value{"arn:aws:iam::123456789012:role/*"})},
+			findings:   []string{"passrole-without-condition/high/0", "wildcard-resource/medium/0"},
+		},
+		{
+			name: "pass role with a condition",
+			statements: []Statement{{
+				Effect:    EffectAllow,
+				Action:    Value{"iam:PassRole"},
+				Resource:  Value{"arn:aws:iam::123456789012:role/worker"},
+				Condition: StringEquals("iam:PassedToService", "lambda.amazonaws.com"),
+			}},
+			findings: []string{},
+		},
+		{
+			name:       "privilege escalation with a single action",
+			statements: []Statement{allow(Value{"iam:PutRolePolicy"}, Value{"arn:aws:iam::123456789012:role/worker"})},
+			findings:   []string{"privilege-escalation/high/-1"},
+		},
+		{
+			name: "privilege escalation across statements",
+			statements: []Statement{
+				allow(Value{"iam:UpdateAssumeRolePolicy"}, Value{"arn:aws:iam::123456789012:role/worker"}),
+				allow(Value{"sts:AssumeRole"}, Value{"arn:aws:iam::123456789012:role/worker"}),
+			},
+			findings: []string{"privilege-escalation/high/-1"},
+		},
+		{
+			name: "privilege escalation denied on all resources",
+			statements: []Statement{
+				allow(Value{"iam:PutRolePolicy"}, Value{"arn:aws:iam::123456789012:role/worker"}),
+				{Effect: EffectDeny, Action: Value{"iam:Put*"}, Resource: Value{"*"}},
+			},
+			findings: []string{},
+		},
+		{
+			name: "privilege escalation denied with a condition",
+			statements: []Statement{
+				allow(Value{"iam:PutRolePolicy"}, Value{"arn:aws:iam::123456789012:role/worker"}),
+				{Effect: EffectDeny, Action: Value{"iam:PutRolePolicy"}, Resource: Value{"*"}, Condition: Bool("aws:MultiFactorAuthPresent", false)},
+			},
+			findings: []string{"privilege-escalation/high/-1"},
+		},
+		{
+			name: "privilege escalation denied on a single role",
+			statements: []Statement{
+				allow(Value{"iam:PutRolePolicy"}, Value{"arn:aws:iam::123456789012:role/*"}),
+				{Effect: EffectDeny, Action: Value{"iam:PutRolePolicy"}, Resource: Value{"arn:aws:iam::123456789012:role/admin"}},
+			},
+			findings: []string{"privilege-escalation/high/-1", "wildcard-resource/medium/0"},
+		},
+		{
+			name: "suppressed statement",
+			statements: []Statement{
+				{Sid: "Admin", Effect: EffectAllow, Action: Value{"iam:*"}, Resource: Value{"*"}},
+				allow(Value{"dynamodb:GetItem"}, Value{"*"}),
+			},
+			suppressed: []string{"Admin"},
+			findings:   []string{"wildcard-resource/medium/1"},
+		},
+		{
+			name: "sorted by severity",
+			statements: []Statement{
+				allow(Value{"dynamodb:Get*"}, Value{"*"}),
+				allow(Value{"dynamodb:*"}, table),
+				allow(Value{"iam:PutRolePolicy"}, Value{"arn:aws:iam::123456789012:role/worker"}),
+			},
+			findings: []string{"privilege-escalation/high/-1", "wildcard-resource/medium/0", "wildcard-action/medium/1", "wildcard-action/low/0"},
+		},
+	}
+
+	for _, tt := range tests {
+		doc := &PolicyDocument{Version: PolicyVersion, Statement: tt.statements}
+		if got := findingKeys(doc.Lint(tt.suppressed...), tt.rule); !reflect.DeepEqual(got, tt.findings) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.findings)
+		}
+	}
+}
+
+func TestLintReportHasSeverity(t *testing.T) {
+	doc := &PolicyDocument{Version: PolicyVersion, Statement: []Statement{
+		{Effect: EffectAllow, Action: Value{"dynamodb:GetItem"}, Resource: Value{"*"}},
+	}}
+	report := doc.Lint()
+
+	if !report.HasSeverity(SeverityLow) || !report.HasSeverity(SeverityMedium) {
+		t.Error("expected a medium finding to meet the low and medium severities")
+	}
+	if report.HasSeverity(SeverityHigh) {
+		t.Error("expected a medium finding not to meet the high severity")
+	}
+	if (LintReport{}).HasSeverity(SeverityLow) {
+		t.Error("expected an empty report not to meet any severity")
+	}
+}
+
+func TestAllowsAllResources(t *testing.T) {
+	tests := []struct {
+		resource string
+		all      bool
+	}{
+		{"*", true},
+		{"arn:aws:s3:::*", true},
+		{"arn:aws:s3:::*/*", true},
+		{"arn:aws:dynamodb:*:*:table/*", true},
+		{"arn:aws:dynamodb:*:*:table/*/stream/*", false},
+		{"arn:aws:sqs:us-east-1:123456789012:*", true},
+		{"arn:aws:lambda:us-east-1:123456789012:function:*", true},
+		{"arn:aws:s3:::my-bucket/*", false},
+		{"arn:aws:s3:::my-bucket", false},
+		{"arn:aws:dynamodb:*:*:table/orders", false},
+		{"arn:aws:sqs:us-east-1:123456789012:jobs-?", false},
+		{"not-an-arn", false},
+	}
+
+	for _, tt := range tests {
+		if got := allowsAllResources(tt.resource); got != tt.all {
+			t.Errorf("%s: got %v, want %v", tt.resource, got, tt.all)
+		}
+	}
+}
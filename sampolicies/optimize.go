@@ -0,0 +1,224 @@
+package sampolicies
+
+import (
+	"encoding/json"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// optimize returns the statements with duplicates removed, the actions of
+// statements that only differ in their actions merged into a single
+// statement, actions that are covered by a wildcard in the same statement
+// dropped, and everything sorted, so the same policies always result in the
+// same document. Statements with a Sid are never merged with other
+// statements, as that would lose the Sid.
+func optimize(statements []Statement) []Statement {
+	merged := make([]Statement, 0, len(statements))
+	index := make(map[string]int)
+
+	for _, s := range statements {
+		s = s.canonical()
+
+		if len(s.Sid) == 0 && len(s.Action) > 0 {
+			key := mergeKey(s)
+			if idx, ok := index[key]; ok {
+				merged[idx].Action = append(merged[idx].Action, s.Action...)
+				continue
+			}
+			index[key] = len(merged)
+		}
+
+		merged = append(merged, s)
+	}
+
+	keys := make([]string, 0, len(merged))
+	unique := make(map[string]Statement, len(merged))
+
+	for _, s := range merged {
+		s.Action = compactActions(s.Action)
+		s.NotAction = compactActions(s.NotAction)
+
+		key := statementKey(s)
+		if _, ok := unique[key]; ok {
+			continue
+		}
+		unique[key] = s
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	out := make([]Statement, len(keys))
+	for idx, key := range keys {
+		out[idx] = unique[key]
+	}
+	return out
+}
+
+// canonical returns a copy of the statement where the values of every
+// element are sorted and unique.
+func (s Statement) canonical() Statement {
+	s = s.copy()
+	s.Action = s.Action.sorted()
+	s.NotAction = s.NotAction.sorted()
+	s.Resource = s.Resource.sorted()
+	s.NotResource = s.NotResource.sorted()
+	for key, val := range s.Principal {
+		s.Principal[key] = val.sorted()
+	}
+	for key, val := range s.NotPrincipal {
+		s.NotPrincipal[key] = val.sorted()
+	}
+	for _, keys := range s.Condition {
+		for key, val := range keys {
+			keys[key] = val.sorted()
+		}
+	}
+	return s
+}
+
+// sorted returns the sorted, unique values
+func (v Value) sorted() Value {
+	if v == nil {
+		return nil
+	}
+
+	out := make(Value, 0, len(v))
+	seen := make(map[string]bool, len(v))
+	for _, val := range v {
+		if !seen[val] {
+			seen[val] = true
+			out = append(out, val)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// compactActions returns the sorted actions without duplicates and without
+// the actions that are covered by a wildcard action in the same list, like
+// s3:GetObject when s3:Get* is there too. Actions are case insensitive. Of
+// actions that only differ in case, and of wildcards that cover each other,
+// like s3:* and s3:**, the first one in sort order is kept, so the list is
+// never emptied.
+func compactActions(actions Value) Value {
+	if actions == nil {
+		return nil
+	}
+
+	// Sort first, so the same actions are kept whatever their order
+	sorted := append(Value{}, actions...)
+	sort.Strings(sorted)
+
+	unique := make(Value, 0, len(sorted))
+	seen := make(map[string]bool, len(sorted))
+	for _, action := range sorted {
+		if !seen[strings.ToLower(action)] {
+			seen[strings.ToLower(action)] = true
+			unique = append(unique, action)
+		}
+	}
+
+	out := make(Value, 0, len(unique))
+	for i, action := range unique {
+		covered := false
+		for j, other := range unique {
+			if i == j || !strings.ContainsAny(other, "*?") || !matchWildcard(other, action) {
+				continue
+			}
+			// Only drop the action if the other action is kept
+			if j < i || !matchWildcard(action, other) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			out = append(out, action)
+		}
+	}
+
+	sort.Strings(out)
+	return out
+}
+
+// matchWildcard returns true if the value matches the IAM pattern, where *
+// matches any sequence of characters and ? matches a single character. The
+// match is case insensitive, like it is for actions. A ? in the pattern
+// doesn't match a * in the value, so a pattern only matches another pattern
+// it fully covers.
+func matchWildcard(pattern, value string) bool {
+	return wildcardRegexp(pattern, true).MatchString(value)
+}
+
+// wildcardRegexp returns the regular expression for the IAM pattern
+func wildcardRegexp(pattern string, ignoreCase bool) *regexp.Regexp {
+	return compileWildcard(wildcardExpr(pattern), ignoreCase)
+}
+
+// wildcardExpr returns the regular expression for the wildcards and literal
+// characters of an IAM pattern, without anchors. The value it is matched
+// against can be another pattern, so ? doesn't match the * wildcard.
+func wildcardExpr(pattern string) string {
+	return translateWildcards(pattern, `[^*]`)
+}
+
+// valueExpr returns the regular expression for an IAM pattern that is
+// matched against the value of a request, where ? matches any character,
+// including a literal *
+func valueExpr(pattern string) string {
+	return translateWildcards(pattern, ".")
+}
+
+// translateWildcards returns the regular expression for the wildcards and
+// literal characters of the pattern, with anyChar as the expression for ?
+func translateWildcards(pattern string, anyChar string) string {
+	var b strings.Builder
+	for _, r := range pattern {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(anyChar)
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	return b.String()
+}
+
+// wildcardCache holds the compiled expressions of compileWildcard, as the
+// same patterns are matched against many actions and resources
+var wildcardCache sync.Map
+
+// compileWildcard anchors and compiles the expression returned by
+// wildcardExpr. Compiled expressions are cached.
+func compileWildcard(expr string, ignoreCase bool) *regexp.Regexp {
+	flags := "(?s)"
+	if ignoreCase {
+		flags = "(?is)"
+	}
+	expr = flags + "^" + expr + "$"
+
+	if re, ok := wildcardCache.Load(expr); ok {
+		return re.(*regexp.Regexp)
+	}
+	re := regexp.MustCompile(expr)
+	wildcardCache.Store(expr, re)
+	return re
+}
+
+// mergeKey returns the elements of the statement except its actions, so
+// statements that only differ in their actions get the same key.
+func mergeKey(s Statement) string {
+	s.Action = nil
+	return statementKey(s)
+}
+
+// statementKey returns the JSON of the statement. Maps are marshalled with
+// their keys sorted, so equal statements get the same key.
+func statementKey(s Statement) string {
+	b, _ := json.Marshal(s)
+	return string(b)
+}
@@ -0,0 +1,63 @@
+package sampolicies
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompactActions(t *testing.T) {
+	tests := []struct {
+		name    string
+		actions Value
+		want    Value
+	}{
+		{"nil", nil, nil},
+		{"sorted", Value{"sqs:SendMessage", "s3:GetObject"}, Value{"s3:GetObject", "sqs:SendMessage"}},
+		{"duplicates", Value{"s3:GetObject", "s3:GetObject"}, Value{"s3:GetObject"}},
+		{"duplicates in different case", Value{"s3:getobject", "s3:GetObject"}, Value{"s3:GetObject"}},
+		{"covered by wildcard", Value{"s3:GetObject", "s3:Get*", "s3:PutObject"}, Value{"s3:Get*", "s3:PutObject"}},
+		{"covered by single character wildcard", Value{"s3:GetObject", "s3:GetObjec?"}, Value{"s3:GetObjec?"}},
+		{"wildcards that cover each other", Value{"s3:**", "s3:*"}, Value{"s3:*"}},
+		{"wildcards in different case", Value{"s3:get*", "S3:Get*"}, Value{"S3:Get*"}},
+		{"? doesn't cover *", Value{"s3:Get?", "s3:Ge*"}, Value{"s3:Ge*"}},
+		{"? doesn't cover a wildcard it doesn't match", Value{"s3:Get?", "s3:G*t"}, Value{"s3:G*t", "s3:Get?"}},
+	}
+
+	for _, tt := range tests {
+		if got := compactActions(tt.actions); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestOptimize(t *testing.T) {
+	statements := []Statement{
+		{Effect: EffectAllow, Action: Value{"sqs:SendMessage"}, Resource: Value{"arn:aws:sqs:us-east-1:123456789012:jobs"}},
+		{Effect: EffectAllow, Action: Value{"s3:GetObject"}, Resource: Value{"arn:aws:s3:::data/*"}},
+		{Effect: EffectAllow, Action: Value{"s3:PutObject", "s3:GetObject"}, Resource: Value{"arn:aws:s3:::data/*"}},
+		{Effect: EffectAllow, Action: Value{"s3:*"}, Resource: Value{"arn:aws:s3:::data/*"}},
+		{Effect: EffectAllow, Action: Value{"s3:**"}, Resource: Value{"arn:aws:s3:::data/*"}},
+		{Sid: "Keep", Effect: EffectAllow, Action: Value{"s3:GetObject"}, Resource: Value{"arn:aws:s3:::data/*"}},
+		{Effect: EffectAllow, Action: Value{"sqs:SendMessage"}, Resource: Value{"arn:aws:sqs:us-east-1:123456789012:jobs"}},
+	}
+
+	want := []Statement{
+		{Effect: EffectAllow, Action: Value{"s3:*"}, Resource: Value{"arn:aws:s3:::data/*"}},
+		{Effect: EffectAllow, Action: Value{"sqs:SendMessage"}, Resource: Value{"arn:aws:sqs:us-east-1:123456789012:jobs"}},
+		{Sid: "Keep", Effect: EffectAllow, Action: Value{"s3:GetObject"}, Resource: Value{"arn:aws:s3:::data/*"}},
+	}
+
+	got := optimize(statements)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// The same statements in another order give the same result
+	reversed := make([]Statement, len(statements))
+	for idx := range statements {
+		reversed[len(statements)-1-idx] = statements[idx]
+	}
+	if got := optimize(reversed); !reflect.DeepEqual(got, want) {
+		t.Errorf("reversed: got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,153 @@
+package sampolicies
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// UnresolvedPlaceholdersErr is returned when a policy has placeholders the factory has no value for
+	UnresolvedPlaceholdersErr = "factory has unresolved placeholders %s"
+)
+
+var (
+	// placeholderRegexp matches the ${Name} placeholders in a statement
+	placeholderRegexp = regexp.MustCompile(`\$\{([^}]*)\}`)
+
+	// pseudoParameters are the AWS pseudo parameters the factory substitutes
+	pseudoParameters = map[string]bool{
+		"AWS::Partition": true,
+		"AWS::Region":    true,
+		"AWS::AccountId": true,
+		"AWS::URLSuffix": true,
+	}
+
+	// urlSuffixes are the domain suffixes of the AWS partitions
+	urlSuffixes = map[string]string{
+		"aws":        "amazonaws.com",
+		"aws-cn":     "amazonaws.com.cn",
+		"aws-us-gov": "amazonaws.com",
+		"aws-iso":    "c2s.ic.gov",
+		"aws-iso-b":  "sc2s.sgov.gov",
+	}
+)
+
+// WithVariable sets a custom variable that is substituted for ${name} in
+// all policies and returns a pointer to the existing resource to allow
+// chaining. Custom variables can't override the parameters of a template.
+func (f *Factory) WithVariable(name string, value string) *Factory {
+	if f.variables == nil {
+		f.variables = make(map[string]string)
+	}
+	f.variables[name] = value
+	return f
+}
+
+// pseudoParameterValues returns the values of the AWS pseudo parameters
+func (f *Factory) pseudoParameterValues() map[string]string {
+	values := map[string]string{
+		"AWS::Partition": f.Partition(),
+		"AWS::Region":    f.region,
+		"AWS::AccountId": f.accountID,
+	}
+	if suffix, ok := urlSuffixes[f.Partition()]; ok {
+		values["AWS::URLSuffix"] = suffix
+	}
+	return values
+}
+
+// substitute replaces the placeholders in the statement with their values.
+// The replacement is done in a single pass, so placeholders in the values
+// themselves are never substituted. IAM policy variables are left as they
+// are. All other placeholders without a value are left in the statement and
+// returned. Special characters in the values don't need escaping, as they
+// are escaped when the statement is marshalled to JSON.
+func substitute(s Statement, values map[string]string) (Statement, []string) {
+	unresolved := make([]string, 0)
+
+	out := s.mapStrings(func(str string) string {
+		return placeholderRegexp.ReplaceAllStringFunc(str, func(match string) string {
+			name := match[2 : len(match)-1]
+			if val, ok := values[name]; ok {
+				return val
+			}
+			if !isPolicyVariable(name) {
+				unresolved = append(unresolved, name)
+			}
+			return match
+		})
+	})
+
+	return out, unresolved
+}
+
+// placeholders returns the names of all ${Name} placeholders used in the
+// statements.
+func placeholders(statements []Statement) []string {
+	names := make([]string, 0)
+	for _, s := range statements {
+		s.mapStrings(func(str string) string {
+			for _, match := range placeholderRegexp.FindAllStringSubmatch(str, -1) {
+				names = append(names, match[1])
+			}
+			return str
+		})
+	}
+	return names
+}
+
+// policyVariablePrefixes are the prefixes of the IAM policy variables,
+// including those of the web identity providers
+var policyVariablePrefixes = []string{
+	"aws:",
+	"ec2:",
+	"iot:",
+	"s3:",
+	"saml:",
+	"sts:",
+	"accounts.google.com:",
+	"cognito-identity.amazonaws.com:",
+	"graph.facebook.com:",
+	"www.amazon.com:",
+}
+
+// isPolicyVariable returns true if the placeholder is an IAM policy variable,
+// like ${aws:username} or ${*}, which is evaluated by AWS rather than the
+// factory. The prefix is case insensitive, but a pseudo parameter with a
+// single colon, like ${AWS:Region}, is a typo rather than a policy variable.
+func isPolicyVariable(name string) bool {
+	switch name {
+	case "*", "?", "$":
+		return true
+	}
+
+	for param := range pseudoParameters {
+		if strings.EqualFold(name, strings.Replace(param, "::", ":", 1)) {
+			return false
+		}
+	}
+
+	lower := strings.ToLower(name)
+	for _, prefix := range policyVariablePrefixes {
+		if strings.HasPrefix(lower, prefix) && len(lower) > len(prefix) && !strings.Contains(lower, "::") {
+			return true
+		}
+	}
+	return false
+}
+
+// formatPlaceholders returns the sorted, unique placeholders as a list of
+// ${Name} strings.
+func formatPlaceholders(names []string) string {
+	seen := make(map[string]bool, len(names))
+	unique := make([]string, 0, len(names))
+	for _, name := range names {
+		if !seen[name] {
+			seen[name] = true
+			unique = append(unique, "${"+name+"}")
+		}
+	}
+	sort.Strings(unique)
+	return strings.Join(unique, ", ")
+}
@@ -0,0 +1,63 @@
+package sampolicies
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestIsPolicyVariable(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"aws:username", true},
+		{"AWS:UserName", true},
+		{"aws:PrincipalTag/team", true},
+		{"saml:sub", true},
+		{"ec2:SourceInstanceARN", true},
+		{"s3:prefix", true},
+		{"sts:ExternalId", true},
+		{"cognito-identity.amazonaws.com:sub", true},
+		{"*", true},
+		{"?", true},
+		{"$", true},
+		{"AWS:Region", false},
+		{"AWS:AccountId", false},
+		{"aws:partition", false},
+		{"AWS::Region", false},
+		{"aws:", false},
+		{"BucketName", false},
+		{"Stage:Name", false},
+	}
+
+	for _, tt := range tests {
+		if got := isPolicyVariable(tt.name); got != tt.want {
+			t.Errorf("isPolicyVariable(%q) = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUnresolvedPlaceholders(t *testing.T) {
+	tests := []struct {
+		resource   string
+		unresolved string
+	}{
+		{"arn:${AWS::Partition}:s3:::home/${aws:username}/*", ""},
+		{"arn:${AWS::Partition}:sqs:${AWS:Region}:${AWS::AccountId}:jobs", "${AWS:Region}"},
+		{"arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS:AccountId}:jobs", "${AWS:AccountId}"},
+		{"arn:${AWS::Partition}:s3:::${Stage}-uploads/*", "${Stage}"},
+	}
+
+	for _, tt := range tests {
+		f := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1")
+		f.AddStatements(Statement{Effect: EffectAllow, Action: Value{"s3:GetObject"}, Resource: Value{tt.resource}})
+
+		_, err := f.GetPolicyDocument()
+		switch {
+		case len(tt.unresolved) == 0 && err != nil:
+			t.Errorf("%s: unexpected error %s", tt.resource, err)
+		case len(tt.unresolved) > 0 && (err == nil || !strings.Contains(err.Error(), tt.unresolved)):
+			t.Errorf("%s: got error %v, want unresolved %s", tt.resource, err, tt.unresolved)
+		}
+	}
+}
@@ -1,425 +1,1525 @@
-package sampolicies
+// Code generated by policy-generator. DO NOT EDIT.
 
-import "strings"
+package sampolicies
 
-// AddLambdaInvokePolicy Gives permission to invoke a Lambda Function, Alias or Version
-func (f *Factory) AddLambdaInvokePolicy(functionName string) {
-	policy := `{ "Action": ["lambda:InvokeFunction"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:lambda:${AWS::Region}:${AWS::AccountId}:function:${functionName}*" }`
-	policy = strings.ReplaceAll(policy, "${functionName}", functionName)
-	f.policies = append(f.policies, policy)
+import "github.com/pulumi/pulumi/sdk/v2/go/pulumi"
+
+// samTemplates are the AWS SAM policy templates
+var samTemplates = []Template{
+	{
+		Name:        "AMIDescribePolicy",
+		Description: "Gives permissions to describe AMIs",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"ec2:DescribeImages"},
+				Resource: Value{"arn:${AWS::Partition}:ec2:${AWS::Region}:${AWS::AccountId}:image/*"},
+			},
+		},
+	},
+	{
+		Name:        "AWSSecretsManagerGetSecretValuePolicy",
+		Description: "Grants permissions to GetSecretValue for the specified AWS Secrets Manager secret",
+		Parameters: []Parameter{
+			{Name: "SecretArn", Description: "The ARN of the secret to grant access to"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"secretsmanager:GetSecretValue"},
+				Resource: Value{"${SecretArn}"},
+			},
+		},
+	},
+	{
+		Name:        "AWSSecretsManagerRotationPolicy",
+		Description: "Grants permissions to APIs required to rotate a secret in AWS Secrets Manager",
+		Parameters: []Parameter{
+			{Name: "FunctionName", Description: "Name of the Lambda function"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"secretsmanager:DescribeSecret", "secretsmanager:GetSecretValue", "secretsmanager:PutSecretValue", "secretsmanager:UpdateSecretVersionStage"},
+				Resource: Value{"arn:${AWS::Partition}:secretsmanager:${AWS::Region}:${AWS::AccountId}:secret:*"},
+				Condition: Condition{
+					"StringEquals": {
+						"secretsmanager:resource/AllowRotationLambdaArn": Value{"arn:${AWS::Partition}:lambda:${AWS::Region}:${AWS::AccountId}:function:${FunctionName}"},
+					},
+				},
+			},
+			{
+				Effect:   "Allow",
+				Action:   Value{"secretsmanager:GetRandomPassword"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "AthenaQueryPolicy",
+		Description: "Gives permissions to execute Athena queries",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"athena:ListWorkGroups", "athena:GetExecutionEngine", "athena:GetExecutionEngines", "athena:GetNamespace", "athena:GetCatalogs", "athena:GetNamespaces", "athena:GetTables", "athena:GetTable"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "CloudFormationDescribeStacksPolicy",
+		Description: "Gives permission to describe CloudFormation stacks",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"cloudformation:DescribeStacks"},
+				Resource: Value{"arn:${AWS::Partition}:cloudformation:${AWS::Region}:${AWS::AccountId}:stack/*"},
+			},
+		},
+	},
+	{
+		Name:        "CloudWatchDashboardPolicy",
+		Description: "Gives permissions to put metrics to operate on CloudWatch Dashboards",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"cloudwatch:GetDashboard", "cloudwatch:ListDashboards", "cloudwatch:PutDashboard", "cloudwatch:ListMetrics"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "CloudWatchDescribeAlarmHistoryPolicy",
+		Description: "Gives permissions to describe CloudWatch alarm history",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"cloudwatch:DescribeAlarmHistory"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "CloudWatchPutMetricPolicy",
+		Description: "Gives permissions to put metrics to CloudWatch",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"cloudwatch:PutMetricData"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "CodeCommitCrudPolicy",
+		Description: "Gives permissions to create/read/update/delete objects within a specific codecommit repository",
+		Parameters: []Parameter{
+			{Name: "RepositoryName", Description: "Name of the CodeCommit repository"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"codecommit:GitPull", "codecommit:GitPush", "codecommit:CreateBranch", "codecommit:DeleteBranch", "codecommit:GetBranch", "codecommit:ListBranches", "codecommit:MergeBranchesByFastForward", "codecommit:MergeBranchesBySquash", "codecommit:MergeBranchesByThreeWay", "codecommit:UpdateDefaultBranch", "codecommit:BatchDescribeMergeConflicts", "codecommit:CreateUnreferencedMergeCommit", "codecommit:DescribeMergeConflicts", "codecommit:GetMergeCommit", "codecommit:GetMergeOptions", "codecommit:BatchGetPullRequests", "codecommit:CreatePullRequest", "codecommit:DescribePullRequestEvents", "codecommit:GetCommentsForPullRequest", "codecommit:GetCommitsFromMergeBase", "codecommit:GetMergeConflicts", "codecommit:GetPullRequest", "codecommit:ListPullRequests", "codecommit:MergePullRequestByFastForward", "codecommit:MergePullRequestBySquash", "codecommit:MergePullRequestByThreeWay", "codecommit:PostCommentForPullRequest", "codecommit:UpdatePullRequestDescription", "codecommit:UpdatePullRequestStatus", "codecommit:UpdatePullRequestTitle", "codecommit:DeleteFile", "codecommit:GetBlob", "codecommit:GetFile", "codecommit:GetFolder", "codecommit:PutFile", "codecommit:DeleteCommentContent", "codecommit:GetComment", "codecommit:GetCommentsForComparedCommit", "codecommit:PostCommentForComparedCommit", "codecommit:PostCommentReply", "codecommit:UpdateComment", "codecommit:BatchGetCommits", "codecommit:CreateCommit", "codecommit:GetCommit", "codecommit:GetCommitHistory", "codecommit:GetDifferences", "codecommit:GetObjectIdentifier", "codecommit:GetReferences", "codecommit:GetTree", "codecommit:GetRepository", "codecommit:UpdateRepositoryDescription", "codecommit:ListTagsForResource", "codecommit:TagResource", "codecommit:UntagResource", "codecommit:GetRepositoryTriggers", "codecommit:PutRepositoryTriggers", "codecommit:TestRepositoryTriggers", "codecommit:GetBranch", "codecommit:GetCommit", "codecommit:UploadArchive", "codecommit:GetUploadArchiveStatus", "codecommit:CancelUploadArchive"},
+				Resource: Value{"arn:${AWS::Partition}:codecommit:${AWS::Region}:${AWS::AccountId}:${RepositoryName}"},
+			},
+		},
+	},
+	{
+		Name:        "CodeCommitReadPolicy",
+		Description: "Gives permissions to read objects within a specific codecommit repository",
+		Parameters: []Parameter{
+			{Name: "RepositoryName", Description: "Name of the CodeCommit repository"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"codecommit:GitPull", "codecommit:GetBranch", "codecommit:ListBranches", "codecommit:BatchDescribeMergeConflicts", "codecommit:DescribeMergeConflicts", "codecommit:GetMergeCommit", "codecommit:GetMergeOptions", "codecommit:BatchGetPullRequests", "codecommit:DescribePullRequestEvents", "codecommit:GetCommentsForPullRequest", "codecommit:GetCommitsFromMergeBase", "codecommit:GetMergeConflicts", "codecommit:GetPullRequest", "codecommit:ListPullRequests", "codecommit:GetBlob", "codecommit:GetFile", "codecommit:GetFolder", "codecommit:GetComment", "codecommit:GetCommentsForComparedCommit", "codecommit:BatchGetCommits", "codecommit:GetCommit", "codecommit:GetCommitHistory", "codecommit:GetDifferences", "codecommit:GetObjectIdentifier", "codecommit:GetReferences", "codecommit:GetTree", "codecommit:GetRepository", "codecommit:ListTagsForResource", "codecommit:GetRepositoryTriggers", "codecommit:TestRepositoryTriggers", "codecommit:GetBranch", "codecommit:GetCommit", "codecommit:GetUploadArchiveStatus"},
+				Resource: Value{"arn:${AWS::Partition}:codecommit:${AWS::Region}:${AWS::AccountId}:${RepositoryName}"},
+			},
+		},
+	},
+	{
+		Name:        "CodePipelineLambdaExecutionPolicy",
+		Description: "Gives permission for a Lambda function invoked by AWS CodePipeline to report back status of the job",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"codepipeline:PutJobSuccessResult", "codepipeline:PutJobFailureResult"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "CodePipelineReadOnlyPolicy",
+		Description: "Gives read permissions to get details about a CodePipeline pipeline",
+		Parameters: []Parameter{
+			{Name: "PipelineName", Description: "Name of the CodePipeline"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"codepipeline:ListPipelineExecutions"},
+				Resource: Value{"arn:${AWS::Partition}:codepipeline:${AWS::Region}:${AWS::AccountId}:${PipelineName}"},
+			},
+		},
+	},
+	{
+		Name:        "ComprehendBasicAccessPolicy",
+		Description: "Gives access to Amazon Comprehend APIs for detecting entities, key phrases, languages and sentiments",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"comprehend:BatchDetectKeyPhrases", "comprehend:DetectDominantLanguage", "comprehend:DetectEntities", "comprehend:BatchDetectEntities", "comprehend:DetectKeyPhrases", "comprehend:DetectSentiment", "comprehend:BatchDetectDominantLanguage", "comprehend:BatchDetectSentiment"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "CostExplorerReadOnlyPolicy",
+		Description: "Gives access to the readonly Cost Explorer APIs for billing history",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"ce:GetCostAndUsage", "ce:GetDimensionValues", "ce:GetReservationCoverage", "ce:GetReservationPurchaseRecommendation", "ce:GetReservationUtilization", "ce:GetTags"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "DynamoDBBackupFullAccessPolicy",
+		Description: "Gives read/write permissions to DynamoDB on-demand backups for a table",
+		Parameters: []Parameter{
+			{Name: "TableName", Description: "Name of the DynamoDB Table"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"dynamodb:CreateBackup", "dynamodb:DescribeContinuousBackups"},
+				Resource: Value{"arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}"},
+			},
+			{
+				Effect:   "Allow",
+				Action:   Value{"dynamodb:DeleteBackup", "dynamodb:DescribeBackup", "dynamodb:ListBackups"},
+				Resource: Value{"arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}/backup/*"},
+			},
+		},
+	},
+	{
+		Name:        "DynamoDBCrudPolicy",
+		Description: "Gives CRUD access to a DynamoDB Table",
+		Parameters: []Parameter{
+			{Name: "TableName", Description: "Name of the DynamoDB Table"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"dynamodb:GetItem", "dynamodb:DeleteItem", "dynamodb:PutItem", "dynamodb:Scan", "dynamodb:Query", "dynamodb:UpdateItem", "dynamodb:BatchWriteItem", "dynamodb:BatchGetItem", "dynamodb:DescribeTable", "dynamodb:ConditionCheckItem"},
+				Resource: Value{"arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}", "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}/index/*"},
+			},
+		},
+	},
+	{
+		Name:        "DynamoDBReadPolicy",
+		Description: "Gives read only access to a DynamoDB Table",
+		Parameters: []Parameter{
+			{Name: "TableName", Description: "Name of the DynamoDB Table"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"dynamodb:GetItem", "dynamodb:Scan", "dynamodb:Query", "dynamodb:BatchGetItem", "dynamodb:DescribeTable"},
+				Resource: Value{"arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}", "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}/index/*"},
+			},
+		},
+	},
+	{
+		Name:        "DynamoDBReconfigurePolicy",
+		Description: "Gives access reconfigure to a DynamoDB Table",
+		Parameters: []Parameter{
+			{Name: "TableName", Description: "Name of the DynamoDB Table"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"dynamodb:UpdateTable"},
+				Resource: Value{"arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}"},
+			},
+		},
+	},
+	{
+		Name:        "DynamoDBRestoreFromBackupPolicy",
+		Description: "Gives permissions to restore a table from backup",
+		Parameters: []Parameter{
+			{Name: "TableName", Description: "Name of the DynamoDB Table"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"dynamodb:RestoreTableFromBackup"},
+				Resource: Value{"arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}/backup/*"},
+			},
+			{
+				Effect:   "Allow",
+				Action:   Value{"dynamodb:PutItem", "dynamodb:UpdateItem", "dynamodb:DeleteItem", "dynamodb:GetItem", "dynamodb:Query", "dynamodb:Scan", "dynamodb:BatchWriteItem"},
+				Resource: Value{"arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}"},
+			},
+		},
+	},
+	{
+		Name:        "DynamoDBStreamReadPolicy",
+		Description: "Gives permission to describe and read a DynamoDB Stream and Records",
+		Parameters: []Parameter{
+			{Name: "TableName", Description: "Name of the DynamoDB Table"},
+			{Name: "StreamName", Description: "Name of the stream"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"dynamodb:DescribeStream", "dynamodb:GetRecords", "dynamodb:GetShardIterator"},
+				Resource: Value{"arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}/stream/${StreamName}"},
+			},
+			{
+				Effect:   "Allow",
+				Action:   Value{"dynamodb:ListStreams"},
+				Resource: Value{"arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}/stream/*"},
+			},
+		},
+	},
+	{
+		Name:        "DynamoDBWritePolicy",
+		Description: "Gives write only access to a DynamoDB Table",
+		Parameters: []Parameter{
+			{Name: "TableName", Description: "Name of the DynamoDB Table"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"dynamodb:PutItem", "dynamodb:UpdateItem", "dynamodb:BatchWriteItem"},
+				Resource: Value{"arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}", "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${TableName}/index/*"},
+			},
+		},
+	},
+	{
+		Name:        "EC2CopyImagePolicy",
+		Description: "Gives permission top copy EC2 Images",
+		Parameters: []Parameter{
+			{Name: "ImageId", Description: "ID of the image"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"ec2:CopyImage"},
+				Resource: Value{"arn:${AWS::Partition}:ec2:${AWS::Region}:${AWS::AccountId}:image/${ImageId}"},
+			},
+		},
+	},
+	{
+		Name:        "EC2DescribePolicy",
+		Description: "Gives permission to describe EC2 instances",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"ec2:DescribeRegions", "ec2:DescribeInstances"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "EKSDescribePolicy",
+		Description: "Gives permission to describe or list Amazon EKS clusters",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"eks:DescribeCluster", "eks:ListClusters"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "ElasticsearchHttpPostPolicy",
+		Description: "Gives POST and PUT permissions to Elasticsearch",
+		Parameters: []Parameter{
+			{Name: "DomainName", Description: "Name of the Elasticsearch domain"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"es:ESHttpPost", "es:ESHttpPut"},
+				Resource: Value{"arn:${AWS::Partition}:es:${AWS::Region}:${AWS::AccountId}:domain/${DomainName}/*"},
+			},
+		},
+	},
+	{
+		Name:        "EventBridgePutEventsPolicy",
+		Description: "Gives permissions to send events to EventBridge",
+		Parameters: []Parameter{
+			{Name: "EventBusName", Description: "Name of the EventBridge event bus"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"events:PutEvents"},
+				Resource: Value{"arn:${AWS::Partition}:events:${AWS::Region}:${AWS::AccountId}:event-bus/${EventBusName}"},
+			},
+		},
+	},
+	{
+		Name:        "FilterLogEventsPolicy",
+		Description: "Gives permission to filter Log Events from a specified Log Group",
+		Parameters: []Parameter{
+			{Name: "LogGroupName", Description: "Name of the Log Group"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"logs:FilterLogEvents"},
+				Resource: Value{"arn:${AWS::Partition}:logs:${AWS::Region}:${AWS::AccountId}:log-group:${LogGroupName}:log-stream:*"},
+			},
+		},
+	},
+	{
+		Name:        "FirehoseCrudPolicy",
+		Description: "Gives permission to create, write to, update, and delete a Kinesis Firehose Delivery Stream",
+		Parameters: []Parameter{
+			{Name: "DeliveryStreamName", Description: "Name of the Kinesis Firehose Delivery Stream"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"firehose:CreateDeliveryStream", "firehose:DeleteDeliveryStream", "firehose:DescribeDeliveryStream", "firehose:PutRecord", "firehose:PutRecordBatch", "firehose:UpdateDestination"},
+				Resource: Value{"arn:${AWS::Partition}:firehose:${AWS::Region}:${AWS::AccountId}:deliverystream/${DeliveryStreamName}"},
+			},
+		},
+	},
+	{
+		Name:        "FirehoseWritePolicy",
+		Description: "Gives permission to write to a Kinesis Firehose Delivery Stream",
+		Parameters: []Parameter{
+			{Name: "DeliveryStreamName", Description: "Name of the Kinesis Firehose Delivery Stream"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"firehose:PutRecord", "firehose:PutRecordBatch"},
+				Resource: Value{"arn:${AWS::Partition}:firehose:${AWS::Region}:${AWS::AccountId}:deliverystream/${DeliveryStreamName}"},
+			},
+		},
+	},
+	{
+		Name:        "KMSDecryptPolicy",
+		Description: "Gives permission to decrypt with KMS Key",
+		Parameters: []Parameter{
+			{Name: "KeyId", Description: "ID of the KMS key"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"kms:Decrypt"},
+				Resource: Value{"arn:${AWS::Partition}:kms:${AWS::Region}:${AWS::AccountId}:key/${KeyId}"},
+			},
+		},
+	},
+	{
+		Name:        "KMSEncryptPolicy",
+		Description: "Gives permission to encrypt with KMS Key",
+		Parameters: []Parameter{
+			{Name: "KeyId", Description: "ID of the KMS key"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"kms:Encrypt"},
+				Resource: Value{"arn:${AWS::Partition}:kms:${AWS::Region}:${AWS::AccountId}:key/${KeyId}"},
+			},
+		},
+	},
+	{
+		Name:        "KinesisCrudPolicy",
+		Description: "Gives permission to create, publish and delete Kinesis Stream",
+		Parameters: []Parameter{
+			{Name: "StreamName", Description: "Name of the stream"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"kinesis:AddTagsToStream", "kinesis:CreateStream", "kinesis:DecreaseStreamRetentionPeriod", "kinesis:DeleteStream", "kinesis:DescribeStream", "kinesis:DescribeStreamSummary", "kinesis:GetShardIterator", "kinesis:IncreaseStreamRetentionPeriod", "kinesis:ListTagsForStream", "kinesis:MergeShards", "kinesis:PutRecord", "kinesis:PutRecords", "kinesis:SplitShard", "kinesis:RemoveTagsFromStream"},
+				Resource: Value{"arn:${AWS::Partition}:kinesis:${AWS::Region}:${AWS::AccountId}:stream/${StreamName}"},
+			},
+		},
+	},
+	{
+		Name:        "KinesisStreamReadPolicy",
+		Description: "Gives permission to list and read a Kinesis stream",
+		Parameters: []Parameter{
+			{Name: "StreamName", Description: "Name of the stream"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"kinesis:ListStreams", "kinesis:DescribeLimits"},
+				Resource: Value{"arn:${AWS::Partition}:kinesis:${AWS::Region}:${AWS::AccountId}:stream/*"},
+			},
+			{
+				Effect:   "Allow",
+				Action:   Value{"kinesis:DescribeStream", "kinesis:DescribeStreamSummary", "kinesis:GetRecords", "kinesis:GetShardIterator"},
+				Resource: Value{"arn:${AWS::Partition}:kinesis:${AWS::Region}:${AWS::AccountId}:stream/${StreamName}"},
+			},
+		},
+	},
+	{
+		Name:        "LambdaInvokePolicy",
+		Description: "Gives permission to invoke a Lambda Function, Alias or Version",
+		Parameters: []Parameter{
+			{Name: "FunctionName", Description: "Name of the Lambda function"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"lambda:InvokeFunction"},
+				Resource: Value{"arn:${AWS::Partition}:lambda:${AWS::Region}:${AWS::AccountId}:function:${FunctionName}*"},
+			},
+		},
+	},
+	{
+		Name:        "MobileAnalyticsWriteOnlyAccessPolicy",
+		Description: "Gives write only permissions to put event data for all application resources",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"mobileanalytics:PutEvents"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "OrganizationsListAccountsPolicy",
+		Description: "Gives readonly permission to list child account names and ids",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"organizations:ListAccounts"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "PinpointEndpointAccessPolicy",
+		Description: "Gives permissions to get and update endpoints for a Pinpoint application",
+		Parameters: []Parameter{
+			{Name: "PinpointApplicationId", Description: "ID of the Pinpoint application"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"mobiletargeting:GetEndpoint", "mobiletargeting:UpdateEndpoint", "mobiletargeting:UpdateEndpointsBatch"},
+				Resource: Value{"arn:${AWS::Partition}:mobiletargeting:${AWS::Region}:${AWS::AccountId}:apps/${PinpointApplicationId}/endpoints/*"},
+			},
+		},
+	},
+	{
+		Name:        "PollyFullAccessPolicy",
+		Description: "Gives full access permissions to Polly lexicon resources",
+		Parameters: []Parameter{
+			{Name: "LexiconName", Description: "Name of the Polly lexicon"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"polly:GetLexicon", "polly:DeleteLexicon"},
+				Resource: Value{"arn:${AWS::Partition}:polly:${AWS::Region}:${AWS::AccountId}:lexicon/${LexiconName}"},
+			},
+			{
+				Effect:   "Allow",
+				Action:   Value{"polly:DescribeVoices", "polly:ListLexicons", "polly:PutLexicon", "polly:SynthesizeSpeech"},
+				Resource: Value{"arn:${AWS::Partition}:polly:${AWS::Region}:${AWS::AccountId}:lexicon/*"},
+			},
+		},
+	},
+	{
+		Name:        "RekognitionDetectOnlyPolicy",
+		Description: "Gives permission to detect faces, labels and text",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"rekognition:DetectFaces", "rekognition:DetectLabels", "rekognition:DetectModerationLabels", "rekognition:DetectText"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "RekognitionFacesManagementPolicy",
+		Description: "Gives permission to add, delete and search faces in a collection",
+		Parameters: []Parameter{
+			{Name: "CollectionId", Description: "ID of the collection"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"rekognition:IndexFaces", "rekognition:DeleteFaces", "rekognition:SearchFaces", "rekognition:SearchFacesByImage", "rekognition:ListFaces"},
+				Resource: Value{"arn:${AWS::Partition}:rekognition:${AWS::Region}:${AWS::AccountId}:collection/${CollectionId}"},
+			},
+		},
+	},
+	{
+		Name:        "RekognitionFacesPolicy",
+		Description: "Gives permission to compare and detect faces and labels",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"rekognition:CompareFaces", "rekognition:DetectFaces"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "RekognitionLabelsPolicy",
+		Description: "Gives permission to detect object and moderation labels",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"rekognition:DetectLabels", "rekognition:DetectModerationLabels"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "RekognitionNoDataAccessPolicy",
+		Description: "Gives permission to compare and detect faces and labels",
+		Parameters: []Parameter{
+			{Name: "CollectionId", Description: "ID of the collection"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"rekognition:CompareFaces", "rekognition:DetectFaces", "rekognition:DetectLabels", "rekognition:DetectModerationLabels"},
+				Resource: Value{"arn:${AWS::Partition}:rekognition:${AWS::Region}:${AWS::AccountId}:collection/${CollectionId}"},
+			},
+		},
+	},
+	{
+		Name:        "RekognitionReadPolicy",
+		Description: "Gives permission to list and search faces",
+		Parameters: []Parameter{
+			{Name: "CollectionId", Description: "ID of the collection"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"rekognition:ListCollections", "rekognition:ListFaces", "rekognition:SearchFaces", "rekognition:SearchFacesByImage"},
+				Resource: Value{"arn:${AWS::Partition}:rekognition:${AWS::Region}:${AWS::AccountId}:collection/${CollectionId}"},
+			},
+		},
+	},
+	{
+		Name:        "RekognitionWriteOnlyAccessPolicy",
+		Description: "Gives permission to create collection and index faces",
+		Parameters: []Parameter{
+			{Name: "CollectionId", Description: "ID of the collection"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"rekognition:CreateCollection", "rekognition:IndexFaces"},
+				Resource: Value{"arn:${AWS::Partition}:rekognition:${AWS::Region}:${AWS::AccountId}:collection/${CollectionId}"},
+			},
+		},
+	},
+	{
+		Name:        "S3CrudPolicy",
+		Description: "Gives CRUD permissions to objects in the S3 Bucket",
+		Parameters: []Parameter{
+			{Name: "BucketName", Description: "Name of the S3 Bucket"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"s3:GetObject", "s3:ListBucket", "s3:GetBucketLocation", "s3:GetObjectVersion", "s3:PutObject", "s3:PutObjectAcl", "s3:GetLifecycleConfiguration", "s3:PutLifecycleConfiguration", "s3:DeleteObject"},
+				Resource: Value{"arn:${AWS::Partition}:s3:::${BucketName}", "arn:${AWS::Partition}:s3:::${BucketName}/*"},
+			},
+		},
+	},
+	{
+		Name:        "S3FullAccessPolicy",
+		Description: "Gives full access permissions to objects in the S3 Bucket",
+		Parameters: []Parameter{
+			{Name: "BucketName", Description: "Name of the S3 Bucket"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"s3:GetObject", "s3:GetObjectAcl", "s3:GetObjectVersion", "s3:PutObject", "s3:PutObjectAcl", "s3:DeleteObject", "s3:DeleteObjectTagging", "s3:DeleteObjectVersionTagging", "s3:GetObjectTagging", "s3:GetObjectVersionTagging", "s3:PutObjectTagging", "s3:PutObjectVersionTagging"},
+				Resource: Value{"arn:${AWS::Partition}:s3:::${BucketName}/*"},
+			},
+			{
+				Effect:   "Allow",
+				Action:   Value{"s3:ListBucket", "s3:GetBucketLocation", "s3:GetLifecycleConfiguration", "s3:PutLifecycleConfiguration"},
+				Resource: Value{"arn:${AWS::Partition}:s3:::${BucketName}"},
+			},
+		},
+	},
+	{
+		Name:        "S3ReadPolicy",
+		Description: "Gives read permissions to objects in the S3 Bucket",
+		Parameters: []Parameter{
+			{Name: "BucketName", Description: "Name of the S3 Bucket"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"s3:GetObject", "s3:ListBucket", "s3:GetBucketLocation", "s3:GetObjectVersion", "s3:GetLifecycleConfiguration"},
+				Resource: Value{"arn:${AWS::Partition}:s3:::${BucketName}", "arn:${AWS::Partition}:s3:::${BucketName}/*"},
+			},
+		},
+	},
+	{
+		Name:        "S3WritePolicy",
+		Description: "Gives write permissions to objects in the S3 Bucket",
+		Parameters: []Parameter{
+			{Name: "BucketName", Description: "Name of the S3 Bucket"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"s3:PutObject", "s3:PutObjectAcl", "s3:PutLifecycleConfiguration"},
+				Resource: Value{"arn:${AWS::Partition}:s3:::${BucketName}", "arn:${AWS::Partition}:s3:::${BucketName}/*"},
+			},
+		},
+	},
+	{
+		Name:        "SESBulkTemplatedCrudPolicy",
+		Description: "Gives permission to send email, templated email, templated bulk emails and verify identity",
+		Parameters: []Parameter{
+			{Name: "IdentityName", Description: "Name of the SES identity"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"ses:GetIdentityVerificationAttributes", "ses:SendEmail", "ses:SendRawEmail", "ses:SendTemplatedEmail", "ses:SendBulkTemplatedEmail", "ses:VerifyEmailIdentity"},
+				Resource: Value{"arn:${AWS::Partition}:ses:${AWS::Region}:${AWS::AccountId}:identity/${IdentityName}"},
+			},
+		},
+	},
+	{
+		Name:        "SESCrudPolicy",
+		Description: "Gives permission to send email and verify identity",
+		Parameters: []Parameter{
+			{Name: "IdentityName", Description: "Name of the SES identity"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"ses:GetIdentityVerificationAttributes", "ses:SendEmail", "ses:SendRawEmail", "ses:VerifyEmailIdentity"},
+				Resource: Value{"arn:${AWS::Partition}:ses:${AWS::Region}:${AWS::AccountId}:identity/${IdentityName}"},
+			},
+		},
+	},
+	{
+		Name:        "SESEmailTemplateCrudPolicy",
+		Description: "Gives permission to create, get, list, update and delete SES Email Templates",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"ses:CreateTemplate", "ses:GetTemplate", "ses:ListTemplates", "ses:UpdateTemplate", "ses:DeleteTemplate", "ses:TestRenderTemplate"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "SESSendBouncePolicy",
+		Description: "Gives SendBounce permission to a SES identity",
+		Parameters: []Parameter{
+			{Name: "IdentityName", Description: "Name of the SES identity"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"ses:SendBounce"},
+				Resource: Value{"arn:${AWS::Partition}:ses:${AWS::Region}:${AWS::AccountId}:identity/${IdentityName}"},
+			},
+		},
+	},
+	{
+		Name:        "SNSCrudPolicy",
+		Description: "Gives permissions to create, publish and subscribe to SNS topics",
+		Parameters: []Parameter{
+			{Name: "TopicName", Description: "Name of the SNS topic"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"sns:ListSubscriptionsByTopic", "sns:CreateTopic", "sns:SetTopicAttributes", "sns:Subscribe", "sns:Publish"},
+				Resource: Value{"arn:${AWS::Partition}:sns:${AWS::Region}:${AWS::AccountId}:${TopicName}*"},
+			},
+		},
+	},
+	{
+		Name:        "SNSPublishMessagePolicy",
+		Description: "Gives permission to publish message to SNS Topic",
+		Parameters: []Parameter{
+			{Name: "TopicName", Description: "Name of the SNS topic"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"sns:Publish"},
+				Resource: Value{"arn:${AWS::Partition}:sns:${AWS::Region}:${AWS::AccountId}:${TopicName}"},
+			},
+		},
+	},
+	{
+		Name:        "SQSPollerPolicy",
+		Description: "Gives permissions to poll an SQS Queue",
+		Parameters: []Parameter{
+			{Name: "QueueName", Description: "Name of the SQS Queue"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"sqs:ChangeMessageVisibility", "sqs:ChangeMessageVisibilityBatch", "sqs:DeleteMessage", "sqs:DeleteMessageBatch", "sqs:GetQueueAttributes", "sqs:ReceiveMessage"},
+				Resource: Value{"arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${QueueName}"},
+			},
+		},
+	},
+	{
+		Name:        "SQSSendMessagePolicy",
+		Description: "Gives permission to send message to SQS Queue",
+		Parameters: []Parameter{
+			{Name: "QueueName", Description: "Name of the SQS Queue"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"sqs:SendMessage*"},
+				Resource: Value{"arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${QueueName}"},
+			},
+		},
+	},
+	{
+		Name:        "SSMParameterReadPolicy",
+		Description: "Gives access to a parameter to load secrets in this account. If not using default key, KMSDecryptPolicy will also be needed.",
+		Parameters: []Parameter{
+			{Name: "ParameterName", Description: "Name of the parameter, without the leading slash"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"ssm:DescribeParameters"},
+				Resource: Value{"*"},
+			},
+			{
+				Effect:   "Allow",
+				Action:   Value{"ssm:GetParameters", "ssm:GetParameter", "ssm:GetParametersByPath"},
+				Resource: Value{"arn:${AWS::Partition}:ssm:${AWS::Region}:${AWS::AccountId}:parameter/${ParameterName}"},
+			},
+		},
+	},
+	{
+		Name:        "ServerlessRepoReadWriteAccessPolicy",
+		Description: "Gives access permissions to create and list applications in the AWS Serverless Application Repository service",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"serverlessrepo:CreateApplication", "serverlessrepo:CreateApplicationVersion", "serverlessrepo:UpdateApplication", "serverlessrepo:GetApplication", "serverlessrepo:ListApplications", "serverlessrepo:ListApplicationVersions", "serverlessrepo:ListApplicationDependencies"},
+				Resource: Value{"arn:${AWS::Partition}:serverlessrepo:${AWS::Region}:${AWS::AccountId}:applications/*"},
+			},
+		},
+	},
+	{
+		Name:        "StepFunctionsExecutionPolicy",
+		Description: "Gives permission to start a Step Functions state machine execution",
+		Parameters: []Parameter{
+			{Name: "StateMachineName", Description: "Name of the State Machine"},
+		},
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"states:StartExecution"},
+				Resource: Value{"arn:${AWS::Partition}:states:${AWS::Region}:${AWS::AccountId}:stateMachine:${StateMachineName}"},
+			},
+		},
+	},
+	{
+		Name:        "TextractDetectAnalyzePolicy",
+		Description: "Gives access to detect and analyze documents with Textract",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"textract:DetectDocumentText", "textract:StartDocumentTextDetection", "textract:StartDocumentAnalysis", "textract:AnalyzeDocument"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "TextractGetResultPolicy",
+		Description: "Gives access to get detected and analyzed documents from Textract",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"textract:GetDocumentTextDetection", "textract:GetDocumentAnalysis"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "TextractPolicy",
+		Description: "Gives full access to Textract",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"textract:*"},
+				Resource: Value{"*"},
+			},
+		},
+	},
+	{
+		Name:        "VPCAccessPolicy",
+		Description: "Gives access to create, delete, describe and detach ENIs",
+		Statements: []Statement{
+			{
+				Effect:   "Allow",
+				Action:   Value{"ec2:CreateNetworkInterface", "ec2:DeleteNetworkInterface", "ec2:DescribeNetworkInterfaces", "ec2:DetachNetworkInterface"},
+				Resource: Value{"*"},
+			},
+		},
+	},
 }
 
 // AddAMIDescribePolicy Gives permissions to describe AMIs
 func (f *Factory) AddAMIDescribePolicy() {
-	policy := `{ "Action": ["ec2:DescribeImages"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:ec2:${AWS::Region}:${AWS::AccountId}:image/*" }`
-	f.policies = append(f.policies, policy)
+	f.mustAdd("AMIDescribePolicy", nil)
 }
 
-// AddMobileAnalyticsWriteOnlyAccessPolicy Gives write only permissions to put event data for all application resources
-func (f *Factory) AddMobileAnalyticsWriteOnlyAccessPolicy() {
-	policy := `{ "Action": ["mobileanalytics:PutEvents"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddAMIDescribePolicy Gives permissions to describe AMIs
+func (f *InputFactory) AddAMIDescribePolicy() {
+	f.mustAdd("AMIDescribePolicy", nil)
+}
+
+// AddAWSSecretsManagerGetSecretValuePolicy Grants permissions to GetSecretValue for the specified AWS Secrets Manager secret
+func (f *Factory) AddAWSSecretsManagerGetSecretValuePolicy(secretArn string) {
+	f.mustAdd("AWSSecretsManagerGetSecretValuePolicy", map[string]string{"SecretArn": secretArn})
+}
+
+// AddAWSSecretsManagerGetSecretValuePolicy Grants permissions to GetSecretValue for the specified AWS Secrets Manager secret
+func (f *InputFactory) AddAWSSecretsManagerGetSecretValuePolicy(secretArn pulumi.StringInput) {
+	f.mustAdd("AWSSecretsManagerGetSecretValuePolicy", map[string]pulumi.StringInput{"SecretArn": secretArn})
+}
+
+// AddAWSSecretsManagerRotationPolicy Grants permissions to APIs required to rotate a secret in AWS Secrets Manager
+func (f *Factory) AddAWSSecretsManagerRotationPolicy(functionName string) {
+	f.mustAdd("AWSSecretsManagerRotationPolicy", map[string]string{"FunctionName": functionName})
+}
+
+// AddAWSSecretsManagerRotationPolicy Grants permissions to APIs required to rotate a secret in AWS Secrets Manager
+func (f *InputFactory) AddAWSSecretsManagerRotationPolicy(functionName pulumi.StringInput) {
+	f.mustAdd("AWSSecretsManagerRotationPolicy", map[string]pulumi.StringInput{"FunctionName": functionName})
+}
+
+// AddAthenaQueryPolicy Gives permissions to execute Athena queries
+func (f *Factory) AddAthenaQueryPolicy() {
+	f.mustAdd("AthenaQueryPolicy", nil)
+}
+
+// AddAthenaQueryPolicy Gives permissions to execute Athena queries
+func (f *InputFactory) AddAthenaQueryPolicy() {
+	f.mustAdd("AthenaQueryPolicy", nil)
+}
+
+// AddCloudFormationDescribeStacksPolicy Gives permission to describe CloudFormation stacks
+func (f *Factory) AddCloudFormationDescribeStacksPolicy() {
+	f.mustAdd("CloudFormationDescribeStacksPolicy", nil)
+}
+
+// AddCloudFormationDescribeStacksPolicy Gives permission to describe CloudFormation stacks
+func (f *InputFactory) AddCloudFormationDescribeStacksPolicy() {
+	f.mustAdd("CloudFormationDescribeStacksPolicy", nil)
+}
+
+// AddCloudWatchDashboardPolicy Gives permissions to put metrics to operate on CloudWatch Dashboards
+func (f *Factory) AddCloudWatchDashboardPolicy() {
+	f.mustAdd("CloudWatchDashboardPolicy", nil)
+}
+
+// AddCloudWatchDashboardPolicy Gives permissions to put metrics to operate on CloudWatch Dashboards
+func (f *InputFactory) AddCloudWatchDashboardPolicy() {
+	f.mustAdd("CloudWatchDashboardPolicy", nil)
+}
+
+// AddCloudWatchDescribeAlarmHistoryPolicy Gives permissions to describe CloudWatch alarm history
+func (f *Factory) AddCloudWatchDescribeAlarmHistoryPolicy() {
+	f.mustAdd("CloudWatchDescribeAlarmHistoryPolicy", nil)
+}
+
+// AddCloudWatchDescribeAlarmHistoryPolicy Gives permissions to describe CloudWatch alarm history
+func (f *InputFactory) AddCloudWatchDescribeAlarmHistoryPolicy() {
+	f.mustAdd("CloudWatchDescribeAlarmHistoryPolicy", nil)
+}
+
+// AddCloudWatchPutMetricPolicy Gives permissions to put metrics to CloudWatch
+func (f *Factory) AddCloudWatchPutMetricPolicy() {
+	f.mustAdd("CloudWatchPutMetricPolicy", nil)
+}
+
+// AddCloudWatchPutMetricPolicy Gives permissions to put metrics to CloudWatch
+func (f *InputFactory) AddCloudWatchPutMetricPolicy() {
+	f.mustAdd("CloudWatchPutMetricPolicy", nil)
+}
+
+// AddCodeCommitCrudPolicy Gives permissions to create/read/update/delete objects within a specific codecommit repository
+func (f *Factory) AddCodeCommitCrudPolicy(repositoryName string) {
+	f.mustAdd("CodeCommitCrudPolicy", map[string]string{"RepositoryName": repositoryName})
+}
+
+// AddCodeCommitCrudPolicy Gives permissions to create/read/update/delete objects within a specific codecommit repository
+func (f *InputFactory) AddCodeCommitCrudPolicy(repositoryName pulumi.StringInput) {
+	f.mustAdd("CodeCommitCrudPolicy", map[string]pulumi.StringInput{"RepositoryName": repositoryName})
+}
+
+// AddCodeCommitReadPolicy Gives permissions to read objects within a specific codecommit repository
+func (f *Factory) AddCodeCommitReadPolicy(repositoryName string) {
+	f.mustAdd("CodeCommitReadPolicy", map[string]string{"RepositoryName": repositoryName})
+}
+
+// AddCodeCommitReadPolicy Gives permissions to read objects within a specific codecommit repository
+func (f *InputFactory) AddCodeCommitReadPolicy(repositoryName pulumi.StringInput) {
+	f.mustAdd("CodeCommitReadPolicy", map[string]pulumi.StringInput{"RepositoryName": repositoryName})
+}
+
+// AddCodePipelineLambdaExecutionPolicy Gives permission for a Lambda function invoked by AWS CodePipeline to report back status of the job
+func (f *Factory) AddCodePipelineLambdaExecutionPolicy() {
+	f.mustAdd("CodePipelineLambdaExecutionPolicy", nil)
+}
+
+// AddCodePipelineLambdaExecutionPolicy Gives permission for a Lambda function invoked by AWS CodePipeline to report back status of the job
+func (f *InputFactory) AddCodePipelineLambdaExecutionPolicy() {
+	f.mustAdd("CodePipelineLambdaExecutionPolicy", nil)
+}
+
+// AddCodePipelineReadOnlyPolicy Gives read permissions to get details about a CodePipeline pipeline
+func (f *Factory) AddCodePipelineReadOnlyPolicy(pipelineName string) {
+	f.mustAdd("CodePipelineReadOnlyPolicy", map[string]string{"PipelineName": pipelineName})
+}
+
+// AddCodePipelineReadOnlyPolicy Gives read permissions to get details about a CodePipeline pipeline
+func (f *InputFactory) AddCodePipelineReadOnlyPolicy(pipelineName pulumi.StringInput) {
+	f.mustAdd("CodePipelineReadOnlyPolicy", map[string]pulumi.StringInput{"PipelineName": pipelineName})
+}
+
+// AddComprehendBasicAccessPolicy Gives access to Amazon Comprehend APIs for detecting entities, key phrases, languages and sentiments
+func (f *Factory) AddComprehendBasicAccessPolicy() {
+	f.mustAdd("ComprehendBasicAccessPolicy", nil)
+}
+
+// AddComprehendBasicAccessPolicy Gives access to Amazon Comprehend APIs for detecting entities, key phrases, languages and sentiments
+func (f *InputFactory) AddComprehendBasicAccessPolicy() {
+	f.mustAdd("ComprehendBasicAccessPolicy", nil)
+}
+
+// AddCostExplorerReadOnlyPolicy Gives access to the readonly Cost Explorer APIs for billing history
+func (f *Factory) AddCostExplorerReadOnlyPolicy() {
+	f.mustAdd("CostExplorerReadOnlyPolicy", nil)
+}
+
+// AddCostExplorerReadOnlyPolicy Gives access to the readonly Cost Explorer APIs for billing history
+func (f *InputFactory) AddCostExplorerReadOnlyPolicy() {
+	f.mustAdd("CostExplorerReadOnlyPolicy", nil)
+}
+
+// AddDynamoDBBackupFullAccessPolicy Gives read/write permissions to DynamoDB on-demand backups for a table
+func (f *Factory) AddDynamoDBBackupFullAccessPolicy(tableName string) {
+	f.mustAdd("DynamoDBBackupFullAccessPolicy", map[string]string{"TableName": tableName})
+}
+
+// AddDynamoDBBackupFullAccessPolicy Gives read/write permissions to DynamoDB on-demand backups for a table
+func (f *InputFactory) AddDynamoDBBackupFullAccessPolicy(tableName pulumi.StringInput) {
+	f.mustAdd("DynamoDBBackupFullAccessPolicy", map[string]pulumi.StringInput{"TableName": tableName})
 }
 
 // AddDynamoDBCrudPolicy Gives CRUD access to a DynamoDB Table
 func (f *Factory) AddDynamoDBCrudPolicy(tableName string) {
-	policy := `{ "Effect": "Allow", "Action": [ "dynamodb:GetItem", "dynamodb:DeleteItem", "dynamodb:PutItem", "dynamodb:Scan", "dynamodb:Query", "dynamodb:UpdateItem", "dynamodb:BatchWriteItem", "dynamodb:BatchGetItem", "dynamodb:DescribeTable", "dynamodb:ConditionCheckItem" ], "Resource": [ "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${tableName}", "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${tableName}/index/*" ] }`
-	policy = strings.ReplaceAll(policy, "${tableName}", tableName)
-	f.policies = append(f.policies, policy)
+	f.mustAdd("DynamoDBCrudPolicy", map[string]string{"TableName": tableName})
 }
 
-// AddElasticsearchHttpPostPolicy Gives POST and PUT permissions to Elasticsearch
-func (f *Factory) AddElasticsearchHttpPostPolicy(domainName string) {
-	policy := `{ "Action": ["es:ESHttpPost","es:ESHttpPut"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:es:${AWS::Region}:${AWS::AccountId}:domain/${domainName}/*" }`
-	policy = strings.ReplaceAll(policy, "${domainName}", domainName)
-	f.policies = append(f.policies, policy)
+// AddDynamoDBCrudPolicy Gives CRUD access to a DynamoDB Table
+func (f *InputFactory) AddDynamoDBCrudPolicy(tableName pulumi.StringInput) {
+	f.mustAdd("DynamoDBCrudPolicy", map[string]pulumi.StringInput{"TableName": tableName})
 }
 
-// AddRekognitionLabelsPolicy Gives permission to detect object and moderation labels
-func (f *Factory) AddRekognitionLabelsPolicy() {
-	policy := `{ "Action": ["rekognition:DetectLabels","rekognition:DetectModerationLabels"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddDynamoDBReadPolicy Gives read only access to a DynamoDB Table
+func (f *Factory) AddDynamoDBReadPolicy(tableName string) {
+	f.mustAdd("DynamoDBReadPolicy", map[string]string{"TableName": tableName})
 }
 
-// AddAWSSecretsManagerGetSecretValuePolicy Grants permissions to GetSecretValue for the specified AWS Secrets Manager secret
-func (f *Factory) AddAWSSecretsManagerGetSecretValuePolicy(secretArn string) {
-	policy := `{ "Action": ["secretsmanager:GetSecretValue"], "Effect": "Allow", "Resource": "${secretArn}" }`
-	policy = strings.ReplaceAll(policy, "${secretArn}", secretArn)
-	f.policies = append(f.policies, policy)
+// AddDynamoDBReadPolicy Gives read only access to a DynamoDB Table
+func (f *InputFactory) AddDynamoDBReadPolicy(tableName pulumi.StringInput) {
+	f.mustAdd("DynamoDBReadPolicy", map[string]pulumi.StringInput{"TableName": tableName})
 }
 
-// AddRekognitionNoDataAccessPolicy Gives permission to compare and detect faces and labels
-func (f *Factory) AddRekognitionNoDataAccessPolicy(collectionId string) {
-	policy := `{ "Action": ["rekognition:CompareFaces","rekognition:DetectFaces","rekognition:DetectLabels","rekognition:DetectModerationLabels"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:rekognition:${AWS::Region}:${AWS::AccountId}:collection/${collectionId}" }`
-	policy = strings.ReplaceAll(policy, "${collectionId}", collectionId)
-	f.policies = append(f.policies, policy)
+// AddDynamoDBReconfigurePolicy Gives access reconfigure to a DynamoDB Table
+func (f *Factory) AddDynamoDBReconfigurePolicy(tableName string) {
+	f.mustAdd("DynamoDBReconfigurePolicy", map[string]string{"TableName": tableName})
 }
 
-// AddSQSSendMessagePolicy Gives permission to send message to SQS Queue
-func (f *Factory) AddSQSSendMessagePolicy(queueName string) {
-	policy := `{ "Action": ["sqs:SendMessage*"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${queueName}" }`
-	policy = strings.ReplaceAll(policy, "${queueName}", queueName)
-	f.policies = append(f.policies, policy)
+// AddDynamoDBReconfigurePolicy Gives access reconfigure to a DynamoDB Table
+func (f *InputFactory) AddDynamoDBReconfigurePolicy(tableName pulumi.StringInput) {
+	f.mustAdd("DynamoDBReconfigurePolicy", map[string]pulumi.StringInput{"TableName": tableName})
 }
 
-// AddCodePipelineReadOnlyPolicy Gives read permissions to get details about a CodePipeline pipeline
-func (f *Factory) AddCodePipelineReadOnlyPolicy(pipelinename string) {
-	policy := `{ "Action": ["codepipeline:ListPipelineExecutions"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:codepipeline:${AWS::Region}:${AWS::AccountId}:${pipelinename}" }`
-	policy = strings.ReplaceAll(policy, "${pipelinename}", pipelinename)
-	f.policies = append(f.policies, policy)
+// AddDynamoDBRestoreFromBackupPolicy Gives permissions to restore a table from backup
+func (f *Factory) AddDynamoDBRestoreFromBackupPolicy(tableName string) {
+	f.mustAdd("DynamoDBRestoreFromBackupPolicy", map[string]string{"TableName": tableName})
 }
 
-// AddSESBulkTemplatedCrudPolicy Gives permission to send email, templated email, templated bulk emails and verify identity
-func (f *Factory) AddSESBulkTemplatedCrudPolicy(identityName string) {
-	policy := `{ "Action": ["ses:GetIdentityVerificationAttributes","ses:SendEmail","ses:SendRawEmail","ses:SendTemplatedEmail","ses:SendBulkTemplatedEmail","ses:VerifyEmailIdentity"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:ses:${AWS::Region}:${AWS::AccountId}:identity/${identityName}" }`
-	policy = strings.ReplaceAll(policy, "${identityName}", identityName)
-	f.policies = append(f.policies, policy)
+// AddDynamoDBRestoreFromBackupPolicy Gives permissions to restore a table from backup
+func (f *InputFactory) AddDynamoDBRestoreFromBackupPolicy(tableName pulumi.StringInput) {
+	f.mustAdd("DynamoDBRestoreFromBackupPolicy", map[string]pulumi.StringInput{"TableName": tableName})
 }
 
-// AddSQSPollerPolicy Gives permissions to poll an SQS Queue
-func (f *Factory) AddSQSPollerPolicy(queueName string) {
-	policy := `{ "Action": ["sqs:ChangeMessageVisibility","sqs:ChangeMessageVisibilityBatch","sqs:DeleteMessage","sqs:DeleteMessageBatch","sqs:GetQueueAttributes","sqs:ReceiveMessage"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${queueName}" }`
-	policy = strings.ReplaceAll(policy, "${queueName}", queueName)
-	f.policies = append(f.policies, policy)
+// AddDynamoDBStreamReadPolicy Gives permission to describe and read a DynamoDB Stream and Records
+func (f *Factory) AddDynamoDBStreamReadPolicy(tableName string, streamName string) {
+	f.mustAdd("DynamoDBStreamReadPolicy", map[string]string{"TableName": tableName, "StreamName": streamName})
 }
 
-// AddRekognitionReadPolicy Gives permission to list and search faces
-func (f *Factory) AddRekognitionReadPolicy(collectionId string) {
-	policy := `{ "Action": ["rekognition:ListCollections","rekognition:ListFaces","rekognition:SearchFaces","rekognition:SearchFacesByImage"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:rekognition:${AWS::Region}:${AWS::AccountId}:collection/${collectionId}" }`
-	policy = strings.ReplaceAll(policy, "${collectionId}", collectionId)
-	f.policies = append(f.policies, policy)
+// AddDynamoDBStreamReadPolicy Gives permission to describe and read a DynamoDB Stream and Records
+func (f *InputFactory) AddDynamoDBStreamReadPolicy(tableName pulumi.StringInput, streamName pulumi.StringInput) {
+	f.mustAdd("DynamoDBStreamReadPolicy", map[string]pulumi.StringInput{"TableName": tableName, "StreamName": streamName})
 }
 
 // AddDynamoDBWritePolicy Gives write only access to a DynamoDB Table
 func (f *Factory) AddDynamoDBWritePolicy(tableName string) {
-	policy := `{ "Effect": "Allow", "Action": [ "dynamodb:PutItem", "dynamodb:UpdateItem", "dynamodb:BatchWriteItem" ], "Resource": [ ""arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${tableName}"", ""arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${tableName}/index/*"" ] }`
-	policy = strings.ReplaceAll(policy, "${tableName}", tableName)
-	f.policies = append(f.policies, policy)
+	f.mustAdd("DynamoDBWritePolicy", map[string]string{"TableName": tableName})
 }
 
-// AddSNSPublishMessagePolicy Gives permission to publish message to SNS Topic
-func (f *Factory) AddSNSPublishMessagePolicy(topicName string) {
-	policy := `{ "Action": ["sns:Publish"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:sns:${AWS::Region}:${AWS::AccountId}:${topicName}" }`
-	policy = strings.ReplaceAll(policy, "${topicName}", topicName)
-	f.policies = append(f.policies, policy)
+// AddDynamoDBWritePolicy Gives write only access to a DynamoDB Table
+func (f *InputFactory) AddDynamoDBWritePolicy(tableName pulumi.StringInput) {
+	f.mustAdd("DynamoDBWritePolicy", map[string]pulumi.StringInput{"TableName": tableName})
 }
 
-// AddKinesisStreamReadPolicy Gives permission to list and read a Kinesis stream
-func (f *Factory) AddKinesisStreamReadPolicy() {
-	policy := `{ "Action": ["kinesis:ListStreams","kinesis:DescribeLimits"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:kinesis:${AWS::Region}:${AWS::AccountId}:stream/*" }`
-	f.policies = append(f.policies, policy)
+// AddEC2CopyImagePolicy Gives permission top copy EC2 Images
+func (f *Factory) AddEC2CopyImagePolicy(imageId string) {
+	f.mustAdd("EC2CopyImagePolicy", map[string]string{"ImageId": imageId})
 }
 
-// AddTextractGetResultPolicy Gives access to get detected and analyzed documents from Textract
-func (f *Factory) AddTextractGetResultPolicy() {
-	policy := `{ "Action": ["textract:GetDocumentTextDetection","textract:GetDocumentAnalysis"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddEC2CopyImagePolicy Gives permission top copy EC2 Images
+func (f *InputFactory) AddEC2CopyImagePolicy(imageId pulumi.StringInput) {
+	f.mustAdd("EC2CopyImagePolicy", map[string]pulumi.StringInput{"ImageId": imageId})
 }
 
-// AddSESCrudPolicy Gives permission to send email and verify identity
-func (f *Factory) AddSESCrudPolicy(identityName string) {
-	policy := `{ "Action": ["ses:GetIdentityVerificationAttributes","ses:SendEmail","ses:SendRawEmail","ses:VerifyEmailIdentity"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:ses:${AWS::Region}:${AWS::AccountId}:identity/${identityName}" }`
-	policy = strings.ReplaceAll(policy, "${identityName}", identityName)
-	f.policies = append(f.policies, policy)
+// AddEC2DescribePolicy Gives permission to describe EC2 instances
+func (f *Factory) AddEC2DescribePolicy() {
+	f.mustAdd("EC2DescribePolicy", nil)
 }
 
-// AddS3FullAccessPolicy Gives full access permissions to objects in the S3 Bucket
-func (f *Factory) AddS3FullAccessPolicy(bucketName string) {
-	policy := `{ "Effect": "Allow", "Action": [ "s3:GetObject", "s3:GetObjectAcl", "s3:GetObjectVersion", "s3:PutObject", "s3:PutObjectAcl", "s3:DeleteObject", "s3:DeleteObjectTagging", "s3:DeleteObjectVersionTagging", "s3:GetObjectTagging", "s3:GetObjectVersionTagging", "s3:PutObjectTagging", "s3:PutObjectVersionTagging" ], "Resource": [ { "arn:${AWS::Partition}:s3:::${bucketName}/*", } ] }, { "Effect": "Allow", "Action": [ "s3:ListBucket", "s3:GetBucketLocation", "s3:GetLifecycleConfiguration", "s3:PutLifecycleConfiguration" ], "Resource": [ "arn:${AWS::Partition}:s3:::${bucketName}"] } ] }`
-	policy = strings.ReplaceAll(policy, "${bucketName}", bucketName)
-	f.policies = append(f.policies, policy)
+// AddEC2DescribePolicy Gives permission to describe EC2 instances
+func (f *InputFactory) AddEC2DescribePolicy() {
+	f.mustAdd("EC2DescribePolicy", nil)
 }
 
-// AddEC2CopyImagePolicy Gives permission top copy EC2 Images
-func (f *Factory) AddEC2CopyImagePolicy(imageId string) {
-	policy := `{ "Action": ["ec2:CopyImage"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:ec2:${AWS::Region}:${AWS::AccountId}:image/${imageId}" }`
-	policy = strings.ReplaceAll(policy, "${imageId}", imageId)
-	f.policies = append(f.policies, policy)
+// AddEKSDescribePolicy Gives permission to describe or list Amazon EKS clusters
+func (f *Factory) AddEKSDescribePolicy() {
+	f.mustAdd("EKSDescribePolicy", nil)
 }
 
-// AddPinpointEndpointAccessPolicy Gives permissions to get and update endpoints for a Pinpoint application
-func (f *Factory) AddPinpointEndpointAccessPolicy(pinpointApplicationId string) {
-	policy := `{ "Action": ["mobiletargeting:GetEndpoint","mobiletargeting:UpdateEndpoint","mobiletargeting:UpdateEndpointsBatch"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:mobiletargeting:${AWS::Region}:${AWS::AccountId}:apps/${pinpointApplicationId}/endpoints/*" }`
-	policy = strings.ReplaceAll(policy, "${pinpointApplicationId}", pinpointApplicationId)
-	f.policies = append(f.policies, policy)
+// AddEKSDescribePolicy Gives permission to describe or list Amazon EKS clusters
+func (f *InputFactory) AddEKSDescribePolicy() {
+	f.mustAdd("EKSDescribePolicy", nil)
 }
 
-// AddRekognitionFacesPolicy Gives permission to compare and detect faces and labels
-func (f *Factory) AddRekognitionFacesPolicy() {
-	policy := `{ "Action": ["rekognition:CompareFaces","rekognition:DetectFaces"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddElasticsearchHttpPostPolicy Gives POST and PUT permissions to Elasticsearch
+func (f *Factory) AddElasticsearchHttpPostPolicy(domainName string) {
+	f.mustAdd("ElasticsearchHttpPostPolicy", map[string]string{"DomainName": domainName})
+}
+
+// AddElasticsearchHttpPostPolicy Gives POST and PUT permissions to Elasticsearch
+func (f *InputFactory) AddElasticsearchHttpPostPolicy(domainName pulumi.StringInput) {
+	f.mustAdd("ElasticsearchHttpPostPolicy", map[string]pulumi.StringInput{"DomainName": domainName})
+}
+
+// AddEventBridgePutEventsPolicy Gives permissions to send events to EventBridge
+func (f *Factory) AddEventBridgePutEventsPolicy(eventBusName string) {
+	f.mustAdd("EventBridgePutEventsPolicy", map[string]string{"EventBusName": eventBusName})
+}
+
+// AddEventBridgePutEventsPolicy Gives permissions to send events to EventBridge
+func (f *InputFactory) AddEventBridgePutEventsPolicy(eventBusName pulumi.StringInput) {
+	f.mustAdd("EventBridgePutEventsPolicy", map[string]pulumi.StringInput{"EventBusName": eventBusName})
 }
 
 // AddFilterLogEventsPolicy Gives permission to filter Log Events from a specified Log Group
 func (f *Factory) AddFilterLogEventsPolicy(logGroupName string) {
-	policy := `{ "Action": ["logs:FilterLogEvents"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:logs:${AWS::Region}:${AWS::AccountId}:log-group:${logGroupName}:log-stream:*" }`
-	policy = strings.ReplaceAll(policy, "${logGroupName}", logGroupName)
-	f.policies = append(f.policies, policy)
+	f.mustAdd("FilterLogEventsPolicy", map[string]string{"LogGroupName": logGroupName})
 }
 
-// AddAthenaQueryPolicy Gives permissions to execute Athena queries
-func (f *Factory) AddAthenaQueryPolicy() {
-	policy := `{ "Action": ["athena:ListWorkGroups","athena:GetExecutionEngine","athena:GetExecutionEngines","athena:GetNamespace","athena:GetCatalogs","athena:GetNamespaces","athena:GetTables","athena:GetTable"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddFilterLogEventsPolicy Gives permission to filter Log Events from a specified Log Group
+func (f *InputFactory) AddFilterLogEventsPolicy(logGroupName pulumi.StringInput) {
+	f.mustAdd("FilterLogEventsPolicy", map[string]pulumi.StringInput{"LogGroupName": logGroupName})
 }
 
-// AddS3WritePolicy Gives write permissions to objects in the S3 Bucket
-func (f *Factory) AddS3WritePolicy(bucketName string) {
-	policy := `{ "Effect": "Allow", "Action": [ "s3:PutObject", "s3:PutObjectAcl", "s3:PutLifecycleConfiguration" ], "Resource": [ "arn:${AWS::Partition}:s3:::${bucketName}","arn:${AWS::Partition}:s3:::${bucketName}/*" ] }`
-	policy = strings.ReplaceAll(policy, "${bucketName}", bucketName)
-	f.policies = append(f.policies, policy)
+// AddFirehoseCrudPolicy Gives permission to create, write to, update, and delete a Kinesis Firehose Delivery Stream
+func (f *Factory) AddFirehoseCrudPolicy(deliveryStreamName string) {
+	f.mustAdd("FirehoseCrudPolicy", map[string]string{"DeliveryStreamName": deliveryStreamName})
 }
 
-// AddVPCAccessPolicy Gives access to create, delete, describe and detach ENIs
-func (f *Factory) AddVPCAccessPolicy() {
-	policy := `{ "Action": ["ec2:CreateNetworkInterface","ec2:DeleteNetworkInterface","ec2:DescribeNetworkInterfaces","ec2:DetachNetworkInterface"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddFirehoseCrudPolicy Gives permission to create, write to, update, and delete a Kinesis Firehose Delivery Stream
+func (f *InputFactory) AddFirehoseCrudPolicy(deliveryStreamName pulumi.StringInput) {
+	f.mustAdd("FirehoseCrudPolicy", map[string]pulumi.StringInput{"DeliveryStreamName": deliveryStreamName})
 }
 
-// AddSNSCrudPolicy Gives permissions to create, publish and subscribe to SNS topics
-func (f *Factory) AddSNSCrudPolicy(topicName string) {
-	policy := `{ "Action": ["sns:ListSubscriptionsByTopic","sns:CreateTopic","sns:SetTopicAttributes","sns:Subscribe","sns:Publish"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:sns:${AWS::Region}:${AWS::AccountId}:${topicName}*" }`
-	policy = strings.ReplaceAll(policy, "${topicName}", topicName)
-	f.policies = append(f.policies, policy)
+// AddFirehoseWritePolicy Gives permission to write to a Kinesis Firehose Delivery Stream
+func (f *Factory) AddFirehoseWritePolicy(deliveryStreamName string) {
+	f.mustAdd("FirehoseWritePolicy", map[string]string{"DeliveryStreamName": deliveryStreamName})
+}
+
+// AddFirehoseWritePolicy Gives permission to write to a Kinesis Firehose Delivery Stream
+func (f *InputFactory) AddFirehoseWritePolicy(deliveryStreamName pulumi.StringInput) {
+	f.mustAdd("FirehoseWritePolicy", map[string]pulumi.StringInput{"DeliveryStreamName": deliveryStreamName})
+}
+
+// AddKMSDecryptPolicy Gives permission to decrypt with KMS Key
+func (f *Factory) AddKMSDecryptPolicy(keyId string) {
+	f.mustAdd("KMSDecryptPolicy", map[string]string{"KeyId": keyId})
+}
+
+// AddKMSDecryptPolicy Gives permission to decrypt with KMS Key
+func (f *InputFactory) AddKMSDecryptPolicy(keyId pulumi.StringInput) {
+	f.mustAdd("KMSDecryptPolicy", map[string]pulumi.StringInput{"KeyId": keyId})
+}
+
+// AddKMSEncryptPolicy Gives permission to encrypt with KMS Key
+func (f *Factory) AddKMSEncryptPolicy(keyId string) {
+	f.mustAdd("KMSEncryptPolicy", map[string]string{"KeyId": keyId})
+}
+
+// AddKMSEncryptPolicy Gives permission to encrypt with KMS Key
+func (f *InputFactory) AddKMSEncryptPolicy(keyId pulumi.StringInput) {
+	f.mustAdd("KMSEncryptPolicy", map[string]pulumi.StringInput{"KeyId": keyId})
 }
 
 // AddKinesisCrudPolicy Gives permission to create, publish and delete Kinesis Stream
 func (f *Factory) AddKinesisCrudPolicy(streamName string) {
-	policy := `{ "Action": ["kinesis:AddTagsToStream","kinesis:CreateStream","kinesis:DecreaseStreamRetentionPeriod","kinesis:DeleteStream","kinesis:DescribeStream","kinesis:DescribeStreamSummary","kinesis:GetShardIterator","kinesis:IncreaseStreamRetentionPeriod","kinesis:ListTagsForStream","kinesis:MergeShards","kinesis:PutRecord","kinesis:PutRecords","kinesis:SplitShard","kinesis:RemoveTagsFromStream"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:kinesis:${AWS::Region}:${AWS::AccountId}:stream/${streamName}" }`
-	policy = strings.ReplaceAll(policy, "${streamName}", streamName)
-	f.policies = append(f.policies, policy)
+	f.mustAdd("KinesisCrudPolicy", map[string]string{"StreamName": streamName})
 }
 
-// AddCostExplorerReadOnlyPolicy Gives access to the readonly Cost Explorer APIs for billing history
-func (f *Factory) AddCostExplorerReadOnlyPolicy() {
-	policy := `{ "Action": ["ce:GetCostAndUsage","ce:GetDimensionValues","ce:GetReservationCoverage","ce:GetReservationPurchaseRecommendation","ce:GetReservationUtilization","ce:GetTags"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddKinesisCrudPolicy Gives permission to create, publish and delete Kinesis Stream
+func (f *InputFactory) AddKinesisCrudPolicy(streamName pulumi.StringInput) {
+	f.mustAdd("KinesisCrudPolicy", map[string]pulumi.StringInput{"StreamName": streamName})
 }
 
-// AddStepFunctionsExecutionPolicy Gives permission to start a Step Functions state machine execution
-func (f *Factory) AddStepFunctionsExecutionPolicy(stateMachineName string) {
-	policy := `{ "Action": ["states:StartExecution"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:states:${AWS::Region}:${AWS::AccountId}:stateMachine:${stateMachineName}" }`
-	policy = strings.ReplaceAll(policy, "${stateMachineName}", stateMachineName)
-	f.policies = append(f.policies, policy)
+// AddKinesisStreamReadPolicy Gives permission to list and read a Kinesis stream
+func (f *Factory) AddKinesisStreamReadPolicy(streamName string) {
+	f.mustAdd("KinesisStreamReadPolicy", map[string]string{"StreamName": streamName})
 }
 
-// AddS3CrudPolicy Gives CRUD permissions to objects in the S3 Bucket
-func (f *Factory) AddS3CrudPolicy(bucketName string) {
-	policy := `{ "Effect": "Allow", "Action": [ "s3:GetObject", "s3:ListBucket", "s3:GetBucketLocation", "s3:GetObjectVersion", "s3:PutObject", "s3:PutObjectAcl", "s3:GetLifecycleConfiguration", "s3:PutLifecycleConfiguration", "s3:DeleteObject" ], "Resource": ["arn:${AWS::Partition}:s3:::${bucketName}", "arn:${AWS::Partition}:s3:::${bucketName}/*" ] }`
-	policy = strings.ReplaceAll(policy, "${bucketName}", bucketName)
-	f.policies = append(f.policies, policy)
+// AddKinesisStreamReadPolicy Gives permission to list and read a Kinesis stream
+func (f *InputFactory) AddKinesisStreamReadPolicy(streamName pulumi.StringInput) {
+	f.mustAdd("KinesisStreamReadPolicy", map[string]pulumi.StringInput{"StreamName": streamName})
 }
 
-// AddDynamoDBStreamReadPolicy Gives permission to describe and read a DynamoDB Stream and Records
-func (f *Factory) AddDynamoDBStreamReadPolicy() {
-	policy := `{ "Action": ["dynamodb:DescribeStream","dynamodb:GetRecords","dynamodb:GetShardIterator"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${tableName}/stream/${streamName}" }`
-	f.policies = append(f.policies, policy)
+// AddLambdaInvokePolicy Gives permission to invoke a Lambda Function, Alias or Version
+func (f *Factory) AddLambdaInvokePolicy(functionName string) {
+	f.mustAdd("LambdaInvokePolicy", map[string]string{"FunctionName": functionName})
 }
 
-// AddDynamoDBBackupFullAccessPolicy Gives read/write permissions to DynamoDB on-demand backups for a table
-func (f *Factory) AddDynamoDBBackupFullAccessPolicy(tableName string) {
-	policy := `{ "Action": ["dynamodb:CreateBackup","dynamodb:DescribeContinuousBackups"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${tableName}" }`
-	policy = strings.ReplaceAll(policy, "${tableName}", tableName)
-	f.policies = append(f.policies, policy)
+// AddLambdaInvokePolicy Gives permission to invoke a Lambda Function, Alias or Version
+func (f *InputFactory) AddLambdaInvokePolicy(functionName pulumi.StringInput) {
+	f.mustAdd("LambdaInvokePolicy", map[string]pulumi.StringInput{"FunctionName": functionName})
 }
 
-// AddAWSSecretsManagerRotationPolicy Grants permissions to APIs required to rotate a secret in AWS Secrets Manager
-func (f *Factory) AddAWSSecretsManagerRotationPolicy() {
-	policy := `{ "Action": ["secretsmanager:DescribeSecret","secretsmanager:GetSecretValue","secretsmanager:PutSecretValue","secretsmanager:UpdateSecretVersionStage"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:secretsmanager:${AWS::Region}:${AWS::AccountId}:secret:*" }`
-	f.policies = append(f.policies, policy)
+// AddMobileAnalyticsWriteOnlyAccessPolicy Gives write only permissions to put event data for all application resources
+func (f *Factory) AddMobileAnalyticsWriteOnlyAccessPolicy() {
+	f.mustAdd("MobileAnalyticsWriteOnlyAccessPolicy", nil)
 }
 
-// AddS3ReadPolicy Gives read permissions to objects in the S3 Bucket
-func (f *Factory) AddS3ReadPolicy(bucketName string) {
-	policy := `{ "Effect": "Allow", "Action": [ "s3:GetObject", "s3:ListBucket", "s3:GetBucketLocation", "s3:GetObjectVersion", "s3:GetLifecycleConfiguration" ], "Resource": [ "arn:${AWS::Partition}:s3:::${bucketName}","arn:${AWS::Partition}:s3:::${bucketName}/*"] }`
-	policy = strings.ReplaceAll(policy, "${bucketName}", bucketName)
-	f.policies = append(f.policies, policy)
+// AddMobileAnalyticsWriteOnlyAccessPolicy Gives write only permissions to put event data for all application resources
+func (f *InputFactory) AddMobileAnalyticsWriteOnlyAccessPolicy() {
+	f.mustAdd("MobileAnalyticsWriteOnlyAccessPolicy", nil)
 }
 
-// AddEKSDescribePolicy Gives permission to describe or list Amazon EKS clusters
-func (f *Factory) AddEKSDescribePolicy() {
-	policy := `{ "Action": ["eks:DescribeCluster","eks:ListClusters"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddOrganizationsListAccountsPolicy Gives readonly permission to list child account names and ids
+func (f *Factory) AddOrganizationsListAccountsPolicy() {
+	f.mustAdd("OrganizationsListAccountsPolicy", nil)
 }
 
-// AddCloudWatchDescribeAlarmHistoryPolicy Gives permissions to describe CloudWatch alarm history
-func (f *Factory) AddCloudWatchDescribeAlarmHistoryPolicy() {
-	policy := `{ "Action": ["cloudwatch:DescribeAlarmHistory"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddOrganizationsListAccountsPolicy Gives readonly permission to list child account names and ids
+func (f *InputFactory) AddOrganizationsListAccountsPolicy() {
+	f.mustAdd("OrganizationsListAccountsPolicy", nil)
 }
 
-// AddRekognitionDetectOnlyPolicy Gives permission to detect faces, labels and text
-func (f *Factory) AddRekognitionDetectOnlyPolicy() {
-	policy := `{ "Action": ["rekognition:DetectFaces","rekognition:DetectLabels","rekognition:DetectModerationLabels","rekognition:DetectText"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddPinpointEndpointAccessPolicy Gives permissions to get and update endpoints for a Pinpoint application
+func (f *Factory) AddPinpointEndpointAccessPolicy(pinpointApplicationId string) {
+	f.mustAdd("PinpointEndpointAccessPolicy", map[string]string{"PinpointApplicationId": pinpointApplicationId})
+}
+
+// AddPinpointEndpointAccessPolicy Gives permissions to get and update endpoints for a Pinpoint application
+func (f *InputFactory) AddPinpointEndpointAccessPolicy(pinpointApplicationId pulumi.StringInput) {
+	f.mustAdd("PinpointEndpointAccessPolicy", map[string]pulumi.StringInput{"PinpointApplicationId": pinpointApplicationId})
 }
 
 // AddPollyFullAccessPolicy Gives full access permissions to Polly lexicon resources
 func (f *Factory) AddPollyFullAccessPolicy(lexiconName string) {
-	policy := `{ "Effect": "Allow", "Action": [ "polly:GetLexicon", "polly:DeleteLexicon" ], "Resource": [ { "arn:${AWS::Partition}:polly:${AWS::Region}:${AWS::AccountId}:lexicon/${lexiconName}", } ] }, { "Effect": "Allow", "Action": [ "polly:DescribeVoices", "polly:ListLexicons", "polly:PutLexicon", "polly:SynthesizeSpeech" ], "Resource": [ "arn:${AWS::Partition}:polly:${AWS::Region}:${AWS::AccountId}:lexicon/*" ] }`
-	policy = strings.ReplaceAll(policy, "${lexiconName}", lexiconName)
-	f.policies = append(f.policies, policy)
+	f.mustAdd("PollyFullAccessPolicy", map[string]string{"LexiconName": lexiconName})
 }
 
-// AddEventBridgePutEventsPolicy Gives permissions to send events to EventBridge
-func (f *Factory) AddEventBridgePutEventsPolicy(eventBusName string) {
-	policy := `{ "Action": ["events:PutEvents"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:events:${AWS::Region}:${AWS::AccountId}:event-bus/${eventBusName}" }`
-	policy = strings.ReplaceAll(policy, "${eventBusName}", eventBusName)
-	f.policies = append(f.policies, policy)
+// AddPollyFullAccessPolicy Gives full access permissions to Polly lexicon resources
+func (f *InputFactory) AddPollyFullAccessPolicy(lexiconName pulumi.StringInput) {
+	f.mustAdd("PollyFullAccessPolicy", map[string]pulumi.StringInput{"LexiconName": lexiconName})
 }
 
-// AddSSMParameterReadPolicy Gives access to a parameter to load secrets in this account. If not using default key, KMSDecryptPolicy will also be needed.
-func (f *Factory) AddSSMParameterReadPolicy() {
-	policy := `{ "Action": ["ssm:DescribeParameters"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddRekognitionDetectOnlyPolicy Gives permission to detect faces, labels and text
+func (f *Factory) AddRekognitionDetectOnlyPolicy() {
+	f.mustAdd("RekognitionDetectOnlyPolicy", nil)
 }
 
-// AddCodeCommitCrudPolicy Gives permissions to create/read/update/delete objects within a specific codecommit repository
-func (f *Factory) AddCodeCommitCrudPolicy(repositoryName string) {
-	policy := `{ "Action": ["codecommit:GitPull","codecommit:GitPush","codecommit:CreateBranch","codecommit:DeleteBranch","codecommit:GetBranch","codecommit:ListBranches","codecommit:MergeBranchesByFastForward","codecommit:MergeBranchesBySquash","codecommit:MergeBranchesByThreeWay","codecommit:UpdateDefaultBranch","codecommit:BatchDescribeMergeConflicts","codecommit:CreateUnreferencedMergeCommit","codecommit:DescribeMergeConflicts","codecommit:GetMergeCommit","codecommit:GetMergeOptions","codecommit:BatchGetPullRequests","codecommit:CreatePullRequest","codecommit:DescribePullRequestEvents","codecommit:GetCommentsForPullRequest","codecommit:GetCommitsFromMergeBase","codecommit:GetMergeConflicts","codecommit:GetPullRequest","codecommit:ListPullRequests","codecommit:MergePullRequestByFastForward","codecommit:MergePullRequestBySquash","codecommit:MergePullRequestByThreeWay","codecommit:PostCommentForPullRequest","codecommit:UpdatePullRequestDescription","codecommit:UpdatePullRequestStatus","codecommit:UpdatePullRequestTitle","codecommit:DeleteFile","codecommit:GetBlob","codecommit:GetFile","codecommit:GetFolder","codecommit:PutFile","codecommit:DeleteCommentContent","codecommit:GetComment","codecommit:GetCommentsForComparedCommit","codecommit:PostCommentForComparedCommit","codecommit:PostCommentReply","codecommit:UpdateComment","codecommit:BatchGetCommits","codecommit:CreateCommit","codecommit:GetCommit","codecommit:GetCommitHistory","codecommit:GetDifferences","codecommit:GetObjectIdentifier","codecommit:GetReferences","codecommit:GetTree","codecommit:GetRepository","codecommit:UpdateRepositoryDescription","codecommit:ListTagsForResource","codecommit:TagResource","codecommit:UntagResource","codecommit:GetRepositoryTriggers","codecommit:PutRepositoryTriggers","codecommit:TestRepositoryTriggers","codecommit:GetBranch","codecommit:GetCommit","codecommit:UploadArchive","codecommit:GetUploadArchiveStatus","codecommit:CancelUploadArchive"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:codecommit:${AWS::Region}:${AWS::AccountId}:${repositoryName}" }`
-	policy = strings.ReplaceAll(policy, "${repositoryName}", repositoryName)
-	f.policies = append(f.policies, policy)
+// AddRekognitionDetectOnlyPolicy Gives permission to detect faces, labels and text
+func (f *InputFactory) AddRekognitionDetectOnlyPolicy() {
+	f.mustAdd("RekognitionDetectOnlyPolicy", nil)
 }
 
-// AddCloudWatchPutMetricPolicy Gives permissions to put metrics to CloudWatch
-func (f *Factory) AddCloudWatchPutMetricPolicy() {
-	policy := `{ "Action": ["cloudwatch:PutMetricData"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddRekognitionFacesManagementPolicy Gives permission to add, delete and search faces in a collection
+func (f *Factory) AddRekognitionFacesManagementPolicy(collectionId string) {
+	f.mustAdd("RekognitionFacesManagementPolicy", map[string]string{"CollectionId": collectionId})
 }
 
-// AddEC2DescribePolicy Gives permission to describe EC2 instances
-func (f *Factory) AddEC2DescribePolicy() {
-	policy := `{ "Action": ["ec2:DescribeRegions","ec2:DescribeInstances"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddRekognitionFacesManagementPolicy Gives permission to add, delete and search faces in a collection
+func (f *InputFactory) AddRekognitionFacesManagementPolicy(collectionId pulumi.StringInput) {
+	f.mustAdd("RekognitionFacesManagementPolicy", map[string]pulumi.StringInput{"CollectionId": collectionId})
+}
+
+// AddRekognitionFacesPolicy Gives permission to compare and detect faces and labels
+func (f *Factory) AddRekognitionFacesPolicy() {
+	f.mustAdd("RekognitionFacesPolicy", nil)
+}
+
+// AddRekognitionFacesPolicy Gives permission to compare and detect faces and labels
+func (f *InputFactory) AddRekognitionFacesPolicy() {
+	f.mustAdd("RekognitionFacesPolicy", nil)
+}
+
+// AddRekognitionLabelsPolicy Gives permission to detect object and moderation labels
+func (f *Factory) AddRekognitionLabelsPolicy() {
+	f.mustAdd("RekognitionLabelsPolicy", nil)
+}
+
+// AddRekognitionLabelsPolicy Gives permission to detect object and moderation labels
+func (f *InputFactory) AddRekognitionLabelsPolicy() {
+	f.mustAdd("RekognitionLabelsPolicy", nil)
+}
+
+// AddRekognitionNoDataAccessPolicy Gives permission to compare and detect faces and labels
+func (f *Factory) AddRekognitionNoDataAccessPolicy(collectionId string) {
+	f.mustAdd("RekognitionNoDataAccessPolicy", map[string]string{"CollectionId": collectionId})
+}
+
+// AddRekognitionNoDataAccessPolicy Gives permission to compare and detect faces and labels
+func (f *InputFactory) AddRekognitionNoDataAccessPolicy(collectionId pulumi.StringInput) {
+	f.mustAdd("RekognitionNoDataAccessPolicy", map[string]pulumi.StringInput{"CollectionId": collectionId})
+}
+
+// AddRekognitionReadPolicy Gives permission to list and search faces
+func (f *Factory) AddRekognitionReadPolicy(collectionId string) {
+	f.mustAdd("RekognitionReadPolicy", map[string]string{"CollectionId": collectionId})
+}
+
+// AddRekognitionReadPolicy Gives permission to list and search faces
+func (f *InputFactory) AddRekognitionReadPolicy(collectionId pulumi.StringInput) {
+	f.mustAdd("RekognitionReadPolicy", map[string]pulumi.StringInput{"CollectionId": collectionId})
 }
 
 // AddRekognitionWriteOnlyAccessPolicy Gives permission to create collection and index faces
 func (f *Factory) AddRekognitionWriteOnlyAccessPolicy(collectionId string) {
-	policy := `{ "Action": ["rekognition:CreateCollection","rekognition:IndexFaces"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:rekognition:${AWS::Region}:${AWS::AccountId}:collection/${collectionId}" }`
-	policy = strings.ReplaceAll(policy, "${collectionId}", collectionId)
-	f.policies = append(f.policies, policy)
+	f.mustAdd("RekognitionWriteOnlyAccessPolicy", map[string]string{"CollectionId": collectionId})
 }
 
-// AddServerlessRepoReadWriteAccessPolicy Gives access permissions to create and list applications in the AWS Serverless Application Repository service
-func (f *Factory) AddServerlessRepoReadWriteAccessPolicy() {
-	policy := `{ "Effect": "Allow", "Action": [ "serverlessrepo:CreateApplication", "serverlessrepo:CreateApplicationVersion", "serverlessrepo:UpdateApplication", "serverlessrepo:GetApplication", "serverlessrepo:ListApplications", "serverlessrepo:ListApplicationVersions", "serverlessrepo:ListApplicationDependencies" ], "Resource": [ "arn:${AWS::Partition}:serverlessrepo:${AWS::Region}:${AWS::AccountId}:applications/*" ] }`
-	f.policies = append(f.policies, policy)
+// AddRekognitionWriteOnlyAccessPolicy Gives permission to create collection and index faces
+func (f *InputFactory) AddRekognitionWriteOnlyAccessPolicy(collectionId pulumi.StringInput) {
+	f.mustAdd("RekognitionWriteOnlyAccessPolicy", map[string]pulumi.StringInput{"CollectionId": collectionId})
 }
 
-// AddRekognitionFacesManagementPolicy Gives permission to add, delete and search faces in a collection
-func (f *Factory) AddRekognitionFacesManagementPolicy(collectionId string) {
-	policy := `{ "Action": ["rekognition:IndexFaces","rekognition:DeleteFaces","rekognition:SearchFaces","rekognition:SearchFacesByImage","rekognition:ListFaces"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:rekognition:${AWS::Region}:${AWS::AccountId}:collection/${collectionId}" }`
-	policy = strings.ReplaceAll(policy, "${collectionId}", collectionId)
-	f.policies = append(f.policies, policy)
+// AddS3CrudPolicy Gives CRUD permissions to objects in the S3 Bucket
+func (f *Factory) AddS3CrudPolicy(bucketName string) {
+	f.mustAdd("S3CrudPolicy", map[string]string{"BucketName": bucketName})
 }
 
-// AddFirehoseWritePolicy Gives permission to write to a Kinesis Firehose Delivery Stream
-func (f *Factory) AddFirehoseWritePolicy(deliveryStreamName string) {
-	policy := `{ "Action": ["firehose:PutRecord","firehose:PutRecordBatch"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:firehose:${AWS::Region}:${AWS::AccountId}:deliverystream/${deliveryStreamName}" }`
-	policy = strings.ReplaceAll(policy, "${deliveryStreamName}", deliveryStreamName)
-	f.policies = append(f.policies, policy)
+// AddS3CrudPolicy Gives CRUD permissions to objects in the S3 Bucket
+func (f *InputFactory) AddS3CrudPolicy(bucketName pulumi.StringInput) {
+	f.mustAdd("S3CrudPolicy", map[string]pulumi.StringInput{"BucketName": bucketName})
 }
 
-// AddSESSendBouncePolicy Gives SendBounce permission to a SES identity
-func (f *Factory) AddSESSendBouncePolicy(identityName string) {
-	policy := `{ "Action": ["ses:SendBounce"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:ses:${AWS::Region}:${AWS::AccountId}:identity/${identityName}" }`
-	policy = strings.ReplaceAll(policy, "${identityName}", identityName)
-	f.policies = append(f.policies, policy)
+// AddS3FullAccessPolicy Gives full access permissions to objects in the S3 Bucket
+func (f *Factory) AddS3FullAccessPolicy(bucketName string) {
+	f.mustAdd("S3FullAccessPolicy", map[string]string{"BucketName": bucketName})
 }
 
-// AddCloudFormationDescribeStacksPolicy Gives permission to describe CloudFormation stacks
-func (f *Factory) AddCloudFormationDescribeStacksPolicy() {
-	policy := `{ "Action": ["cloudformation:DescribeStacks"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:cloudformation:${AWS::Region}:${AWS::AccountId}:stack/*" }`
-	f.policies = append(f.policies, policy)
+// AddS3FullAccessPolicy Gives full access permissions to objects in the S3 Bucket
+func (f *InputFactory) AddS3FullAccessPolicy(bucketName pulumi.StringInput) {
+	f.mustAdd("S3FullAccessPolicy", map[string]pulumi.StringInput{"BucketName": bucketName})
 }
 
-// AddKMSDecryptPolicy Gives permission to decrypt with KMS Key
-func (f *Factory) AddKMSDecryptPolicy(keyId string) {
-	policy := `{ "Action": ["kms:Decrypt"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:kms:${AWS::Region}:${AWS::AccountId}:key/${keyId}" }`
-	policy = strings.ReplaceAll(policy, "${keyId}", keyId)
-	f.policies = append(f.policies, policy)
+// AddS3ReadPolicy Gives read permissions to objects in the S3 Bucket
+func (f *Factory) AddS3ReadPolicy(bucketName string) {
+	f.mustAdd("S3ReadPolicy", map[string]string{"BucketName": bucketName})
 }
 
-// AddKMSEncryptPolicy Gives permission to encrypt with KMS Key
-func (f *Factory) AddKMSEncryptPolicy(keyId string) {
-	policy := `{ "Action": ["kms:Encrypt"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:kms:${AWS::Region}:${AWS::AccountId}:key/${keyId}" }`
-	policy = strings.ReplaceAll(policy, "${keyId}", keyId)
-	f.policies = append(f.policies, policy)
+// AddS3ReadPolicy Gives read permissions to objects in the S3 Bucket
+func (f *InputFactory) AddS3ReadPolicy(bucketName pulumi.StringInput) {
+	f.mustAdd("S3ReadPolicy", map[string]pulumi.StringInput{"BucketName": bucketName})
 }
 
-// AddDynamoDBReadPolicy Gives read only access to a DynamoDB Table
-func (f *Factory) AddDynamoDBReadPolicy(tableName string) {
-	policy := `{ "Effect": "Allow", "Action": [ "dynamodb:GetItem", "dynamodb:Scan", "dynamodb:Query", "dynamodb:BatchGetItem", "dynamodb:DescribeTable" ], "Resource": [ "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${tableName}", "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${tableName}/index/*"] }`
-	policy = strings.ReplaceAll(policy, "${tableName}", tableName)
-	f.policies = append(f.policies, policy)
+// AddS3WritePolicy Gives write permissions to objects in the S3 Bucket
+func (f *Factory) AddS3WritePolicy(bucketName string) {
+	f.mustAdd("S3WritePolicy", map[string]string{"BucketName": bucketName})
 }
 
-// AddDynamoDBRestoreFromBackupPolicy Gives permissions to restore a table from backup
-func (f *Factory) AddDynamoDBRestoreFromBackupPolicy(tableName string) {
-	policy := `{ "Action": ["dynamodb:RestoreTableFromBackup"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${tableName}/backup/*" }`
-	policy = strings.ReplaceAll(policy, "${tableName}", tableName)
-	f.policies = append(f.policies, policy)
+// AddS3WritePolicy Gives write permissions to objects in the S3 Bucket
+func (f *InputFactory) AddS3WritePolicy(bucketName pulumi.StringInput) {
+	f.mustAdd("S3WritePolicy", map[string]pulumi.StringInput{"BucketName": bucketName})
+}
+
+// AddSESBulkTemplatedCrudPolicy Gives permission to send email, templated email, templated bulk emails and verify identity
+func (f *Factory) AddSESBulkTemplatedCrudPolicy(identityName string) {
+	f.mustAdd("SESBulkTemplatedCrudPolicy", map[string]string{"IdentityName": identityName})
+}
+
+// AddSESBulkTemplatedCrudPolicy Gives permission to send email, templated email, templated bulk emails and verify identity
+func (f *InputFactory) AddSESBulkTemplatedCrudPolicy(identityName pulumi.StringInput) {
+	f.mustAdd("SESBulkTemplatedCrudPolicy", map[string]pulumi.StringInput{"IdentityName": identityName})
+}
+
+// AddSESCrudPolicy Gives permission to send email and verify identity
+func (f *Factory) AddSESCrudPolicy(identityName string) {
+	f.mustAdd("SESCrudPolicy", map[string]string{"IdentityName": identityName})
+}
+
+// AddSESCrudPolicy Gives permission to send email and verify identity
+func (f *InputFactory) AddSESCrudPolicy(identityName pulumi.StringInput) {
+	f.mustAdd("SESCrudPolicy", map[string]pulumi.StringInput{"IdentityName": identityName})
 }
 
 // AddSESEmailTemplateCrudPolicy Gives permission to create, get, list, update and delete SES Email Templates
 func (f *Factory) AddSESEmailTemplateCrudPolicy() {
-	policy := `{ "Action": ["ses:CreateTemplate","ses:GetTemplate","ses:ListTemplates","ses:UpdateTemplate","ses:DeleteTemplate","ses:TestRenderTemplate"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+	f.mustAdd("SESEmailTemplateCrudPolicy", nil)
+}
+
+// AddSESEmailTemplateCrudPolicy Gives permission to create, get, list, update and delete SES Email Templates
+func (f *InputFactory) AddSESEmailTemplateCrudPolicy() {
+	f.mustAdd("SESEmailTemplateCrudPolicy", nil)
+}
+
+// AddSESSendBouncePolicy Gives SendBounce permission to a SES identity
+func (f *Factory) AddSESSendBouncePolicy(identityName string) {
+	f.mustAdd("SESSendBouncePolicy", map[string]string{"IdentityName": identityName})
+}
+
+// AddSESSendBouncePolicy Gives SendBounce permission to a SES identity
+func (f *InputFactory) AddSESSendBouncePolicy(identityName pulumi.StringInput) {
+	f.mustAdd("SESSendBouncePolicy", map[string]pulumi.StringInput{"IdentityName": identityName})
+}
+
+// AddSNSCrudPolicy Gives permissions to create, publish and subscribe to SNS topics
+func (f *Factory) AddSNSCrudPolicy(topicName string) {
+	f.mustAdd("SNSCrudPolicy", map[string]string{"TopicName": topicName})
+}
+
+// AddSNSCrudPolicy Gives permissions to create, publish and subscribe to SNS topics
+func (f *InputFactory) AddSNSCrudPolicy(topicName pulumi.StringInput) {
+	f.mustAdd("SNSCrudPolicy", map[string]pulumi.StringInput{"TopicName": topicName})
+}
+
+// AddSNSPublishMessagePolicy Gives permission to publish message to SNS Topic
+func (f *Factory) AddSNSPublishMessagePolicy(topicName string) {
+	f.mustAdd("SNSPublishMessagePolicy", map[string]string{"TopicName": topicName})
+}
+
+// AddSNSPublishMessagePolicy Gives permission to publish message to SNS Topic
+func (f *InputFactory) AddSNSPublishMessagePolicy(topicName pulumi.StringInput) {
+	f.mustAdd("SNSPublishMessagePolicy", map[string]pulumi.StringInput{"TopicName": topicName})
+}
+
+// AddSQSPollerPolicy Gives permissions to poll an SQS Queue
+func (f *Factory) AddSQSPollerPolicy(queueName string) {
+	f.mustAdd("SQSPollerPolicy", map[string]string{"QueueName": queueName})
+}
+
+// AddSQSPollerPolicy Gives permissions to poll an SQS Queue
+func (f *InputFactory) AddSQSPollerPolicy(queueName pulumi.StringInput) {
+	f.mustAdd("SQSPollerPolicy", map[string]pulumi.StringInput{"QueueName": queueName})
+}
+
+// AddSQSSendMessagePolicy Gives permission to send message to SQS Queue
+func (f *Factory) AddSQSSendMessagePolicy(queueName string) {
+	f.mustAdd("SQSSendMessagePolicy", map[string]string{"QueueName": queueName})
+}
+
+// AddSQSSendMessagePolicy Gives permission to send message to SQS Queue
+func (f *InputFactory) AddSQSSendMessagePolicy(queueName pulumi.StringInput) {
+	f.mustAdd("SQSSendMessagePolicy", map[string]pulumi.StringInput{"QueueName": queueName})
+}
+
+// AddSSMParameterReadPolicy Gives access to a parameter to load secrets in this account. If not using default key, KMSDecryptPolicy will also be needed.
+func (f *Factory) AddSSMParameterReadPolicy(parameterName string) {
+	f.mustAdd("SSMParameterReadPolicy", map[string]string{"ParameterName": parameterName})
+}
+
+// AddSSMParameterReadPolicy Gives access to a parameter to load secrets in this account. If not using default key, KMSDecryptPolicy will also be needed.
+func (f *InputFactory) AddSSMParameterReadPolicy(parameterName pulumi.StringInput) {
+	f.mustAdd("SSMParameterReadPolicy", map[string]pulumi.StringInput{"ParameterName": parameterName})
+}
+
+// AddServerlessRepoReadWriteAccessPolicy Gives access permissions to create and list applications in the AWS Serverless Application Repository service
+func (f *Factory) AddServerlessRepoReadWriteAccessPolicy() {
+	f.mustAdd("ServerlessRepoReadWriteAccessPolicy", nil)
+}
+
+// AddServerlessRepoReadWriteAccessPolicy Gives access permissions to create and list applications in the AWS Serverless Application Repository service
+func (f *InputFactory) AddServerlessRepoReadWriteAccessPolicy() {
+	f.mustAdd("ServerlessRepoReadWriteAccessPolicy", nil)
+}
+
+// AddStepFunctionsExecutionPolicy Gives permission to start a Step Functions state machine execution
+func (f *Factory) AddStepFunctionsExecutionPolicy(stateMachineName string) {
+	f.mustAdd("StepFunctionsExecutionPolicy", map[string]string{"StateMachineName": stateMachineName})
+}
+
+// AddStepFunctionsExecutionPolicy Gives permission to start a Step Functions state machine execution
+func (f *InputFactory) AddStepFunctionsExecutionPolicy(stateMachineName pulumi.StringInput) {
+	f.mustAdd("StepFunctionsExecutionPolicy", map[string]pulumi.StringInput{"StateMachineName": stateMachineName})
 }
 
 // AddTextractDetectAnalyzePolicy Gives access to detect and analyze documents with Textract
 func (f *Factory) AddTextractDetectAnalyzePolicy() {
-	policy := `{ "Action": ["textract:DetectDocumentText","textract:StartDocumentTextDetection","textract:StartDocumentAnalysis","textract:AnalyzeDocument"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+	f.mustAdd("TextractDetectAnalyzePolicy", nil)
 }
 
-// AddCloudWatchDashboardPolicy Gives permissions to put metrics to operate on CloudWatch Dashboards
-func (f *Factory) AddCloudWatchDashboardPolicy() {
-	policy := `{ "Action": ["cloudwatch:GetDashboard","cloudwatch:ListDashboards","cloudwatch:PutDashboard","cloudwatch:ListMetrics"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddTextractDetectAnalyzePolicy Gives access to detect and analyze documents with Textract
+func (f *InputFactory) AddTextractDetectAnalyzePolicy() {
+	f.mustAdd("TextractDetectAnalyzePolicy", nil)
 }
 
-// AddComprehendBasicAccessPolicy Gives access to Amazon Comprehend APIs for detecting entities, key phrases, languages and sentiments
-func (f *Factory) AddComprehendBasicAccessPolicy() {
-	policy := `{ "Action": ["comprehend:BatchDetectKeyPhrases","comprehend:DetectDominantLanguage","comprehend:DetectEntities","comprehend:BatchDetectEntities","comprehend:DetectKeyPhrases","comprehend:DetectSentiment","comprehend:BatchDetectDominantLanguage","comprehend:BatchDetectSentiment"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddTextractGetResultPolicy Gives access to get detected and analyzed documents from Textract
+func (f *Factory) AddTextractGetResultPolicy() {
+	f.mustAdd("TextractGetResultPolicy", nil)
 }
 
-// AddFirehoseCrudPolicy Gives permission to create, write to, update, and delete a Kinesis Firehose Delivery Stream
-func (f *Factory) AddFirehoseCrudPolicy(deliveryStreamName string) {
-	policy := `{ "Action": ["firehose:CreateDeliveryStream","firehose:DeleteDeliveryStream","firehose:DescribeDeliveryStream","firehose:PutRecord","firehose:PutRecordBatch","firehose:UpdateDestination"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:firehose:${AWS::Region}:${AWS::AccountId}:deliverystream/${deliveryStreamName}" }`
-	policy = strings.ReplaceAll(policy, "${deliveryStreamName}", deliveryStreamName)
-	f.policies = append(f.policies, policy)
+// AddTextractGetResultPolicy Gives access to get detected and analyzed documents from Textract
+func (f *InputFactory) AddTextractGetResultPolicy() {
+	f.mustAdd("TextractGetResultPolicy", nil)
 }
 
 // AddTextractPolicy Gives full access to Textract
 func (f *Factory) AddTextractPolicy() {
-	policy := `{ "Action": ["textract:*"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+	f.mustAdd("TextractPolicy", nil)
 }
 
-// AddDynamoDBReconfigurePolicy Gives access reconfigure to a DynamoDB Table
-func (f *Factory) AddDynamoDBReconfigurePolicy(tableName string) {
-	policy := `{ "Action": ["dynamodb:UpdateTable"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:dynamodb:${AWS::Region}:${AWS::AccountId}:table/${tableName}" }`
-	policy = strings.ReplaceAll(policy, "${tableName}", tableName)
-	f.policies = append(f.policies, policy)
-}
-
-// AddCodePipelineLambdaExecutionPolicy Gives permission for a Lambda function invoked by AWS CodePipeline to report back status of the job
-func (f *Factory) AddCodePipelineLambdaExecutionPolicy() {
-	policy := `{ "Action": ["codepipeline:PutJobSuccessResult","codepipeline:PutJobFailureResult"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddTextractPolicy Gives full access to Textract
+func (f *InputFactory) AddTextractPolicy() {
+	f.mustAdd("TextractPolicy", nil)
 }
 
-// AddOrganizationsListAccountsPolicy Gives readonly permission to list child account names and ids
-func (f *Factory) AddOrganizationsListAccountsPolicy() {
-	policy := `{ "Action": ["organizations:ListAccounts"], "Effect": "Allow", "Resource": "*" }`
-	f.policies = append(f.policies, policy)
+// AddVPCAccessPolicy Gives access to create, delete, describe and detach ENIs
+func (f *Factory) AddVPCAccessPolicy() {
+	f.mustAdd("VPCAccessPolicy", nil)
 }
 
-// AddCodeCommitReadPolicy Gives permissions to read objects within a specific codecommit repository
-func (f *Factory) AddCodeCommitReadPolicy(repositoryName string) {
-	policy := `{ "Action": ["codecommit:GitPull","codecommit:GetBranch","codecommit:ListBranches","codecommit:BatchDescribeMergeConflicts","codecommit:DescribeMergeConflicts","codecommit:GetMergeCommit","codecommit:GetMergeOptions","codecommit:BatchGetPullRequests","codecommit:DescribePullRequestEvents","codecommit:GetCommentsForPullRequest","codecommit:GetCommitsFromMergeBase","codecommit:GetMergeConflicts","codecommit:GetPullRequest","codecommit:ListPullRequests","codecommit:GetBlob","codecommit:GetFile","codecommit:GetFolder","codecommit:GetComment","codecommit:GetCommentsForComparedCommit","codecommit:BatchGetCommits","codecommit:GetCommit","codecommit:GetCommitHistory","codecommit:GetDifferences","codecommit:GetObjectIdentifier","codecommit:GetReferences","codecommit:GetTree","codecommit:GetRepository","codecommit:ListTagsForResource","codecommit:GetRepositoryTriggers","codecommit:TestRepositoryTriggers","codecommit:GetBranch","codecommit:GetCommit","codecommit:GetUploadArchiveStatus"], "Effect": "Allow", "Resource": "arn:${AWS::Partition}:codecommit:${AWS::Region}:${AWS::AccountId}:${repositoryName}" }`
-	policy = strings.ReplaceAll(policy, "${repositoryName}", repositoryName)
-	f.policies = append(f.policies, policy)
+// AddVPCAccessPolicy Gives access to create, delete, describe and detach ENIs
+func (f *InputFactory) AddVPCAccessPolicy() {
+	f.mustAdd("VPCAccessPolicy", nil)
 }
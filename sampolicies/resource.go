@@ -0,0 +1,125 @@
+package sampolicies
+
+import (
+	"fmt"
+)
+
+// The names of the AWS services that commonly send to or read from another
+// resource, next to the services that assume a role.
+const (
+	ServiceS3           = "s3"
+	ServiceS3Logging    = "logging.s3"
+	ServiceSNS          = "sns"
+	ServiceSQS          = "sqs"
+	ServiceCloudWatch   = "cloudwatch"
+	ServiceCloudTrail   = "cloudtrail"
+	ServiceLogsDelivery = "delivery.logs"
+)
+
+// AddBucketServiceAccess allows the AWS service to write objects to the S3
+// bucket, like ServiceS3Logging for server access logs. The service can only
+// write on behalf of the sourceArn, if it isn't empty, and of resources in
+// the account of the factory. It can only be used in a factory that builds a
+// ResourcePolicy.
+func (f *Factory) AddBucketServiceAccess(bucketName string, service string, sourceArn string) {
+	f.addServiceAccess("BucketServiceAccess", map[string]string{"BucketName": bucketName}, service, sourceArn,
+		Value{"s3:PutObject"}, Value{"arn:${AWS::Partition}:s3:::${BucketName}/*"})
+}
+
+// AddBucketDenyInsecureTransport denies everyone access to the S3 bucket over
+// plain HTTP. It can only be used in a factory that builds a ResourcePolicy.
+func (f *Factory) AddBucketDenyInsecureTransport(bucketName string) {
+	f.addPolicy("BucketDenyInsecureTransport", map[string]string{"BucketName": bucketName}, Statement{
+		Sid:       "DenyInsecureTransport",
+		Effect:    EffectDeny,
+		Principal: Principal{"AWS": Value{"*"}},
+		Action:    Value{"s3:*"},
+		Resource:  Value{"arn:${AWS::Partition}:s3:::${BucketName}", "arn:${AWS::Partition}:s3:::${BucketName}/*"},
+		Condition: Bool("aws:SecureTransport", false),
+	})
+}
+
+// AddQueueServiceAccess allows the AWS service to send messages to the SQS
+// queue, like ServiceSNS for a subscription or ServiceEventBridge for a rule
+// target. The service can only send on behalf of the sourceArn, if it isn't
+// empty, and of resources in the account of the factory. It can only be used
+// in a factory that builds a ResourcePolicy.
+func (f *Factory) AddQueueServiceAccess(queueName string, service string, sourceArn string) {
+	f.addServiceAccess("QueueServiceAccess", map[string]string{"QueueName": queueName}, service, sourceArn,
+		Value{"sqs:SendMessage"}, Value{"arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${QueueName}"})
+}
+
+// AddTopicServiceAccess allows the AWS service to publish to the SNS topic,
+// like ServiceEventBridge for a rule target or ServiceS3 for event
+// notifications. The service can only publish on behalf of the sourceArn, if
+// it isn't empty, and of resources in the account of the factory. It can only
+// be used in a factory that builds a ResourcePolicy.
+func (f *Factory) AddTopicServiceAccess(topicName string, service string, sourceArn string) {
+	f.addServiceAccess("TopicServiceAccess", map[string]string{"TopicName": topicName}, service, sourceArn,
+		Value{"sns:Publish"}, Value{"arn:${AWS::Partition}:sns:${AWS::Region}:${AWS::AccountId}:${TopicName}"})
+}
+
+// AddFunctionServiceAccess allows the AWS service to invoke the Lambda
+// function, like ServiceS3 for event notifications or ServiceEventBridge for
+// a rule target. The service can only invoke the function on behalf of the
+// sourceArn, if it isn't empty, and of resources in the account of the
+// factory. It can only be used in a factory that builds a ResourcePolicy.
+func (f *Factory) AddFunctionServiceAccess(functionName string, service string, sourceArn string) {
+	f.addServiceAccess("FunctionServiceAccess", map[string]string{"FunctionName": functionName}, service, sourceArn,
+		Value{"lambda:InvokeFunction"}, Value{"arn:${AWS::Partition}:lambda:${AWS::Region}:${AWS::AccountId}:function:${FunctionName}"})
+}
+
+// AddKeyAccountAccess gives the account of the factory full access to the KMS
+// key, so IAM policies in the account can grant access to it. Every key
+// policy needs this statement, or the key can become unmanageable. It can
+// only be used in a factory that builds a ResourcePolicy.
+func (f *Factory) AddKeyAccountAccess() {
+	f.addPolicy("KeyAccountAccess", nil, Statement{
+		Sid:       "EnableIAMUserPermissions",
+		Effect:    EffectAllow,
+		Principal: Principal{"AWS": Value{"arn:${AWS::Partition}:iam::${AWS::AccountId}:root"}},
+		Action:    Value{"kms:*"},
+		Resource:  Value{"*"},
+	})
+}
+
+// AddKeyServiceAccess allows the AWS service to use the KMS key to encrypt
+// and decrypt data, like ServiceSNS for an encrypted topic. The service can
+// only use the key on behalf of the sourceArn, if it isn't empty, and of
+// resources in the account of the factory. It can only be used in a factory
+// that builds a ResourcePolicy.
+func (f *Factory) AddKeyServiceAccess(service string, sourceArn string) {
+	f.addServiceAccess("KeyServiceAccess", nil, service, sourceArn,
+		Value{"kms:Decrypt", "kms:GenerateDataKey*"}, Value{"*"})
+}
+
+// addServiceAccess adds a statement that allows the AWS service to perform
+// the actions on the resources, on behalf of resources in the account of the
+// factory and, if it isn't empty, the sourceArn.
+func (f *Factory) addServiceAccess(name string, params map[string]string, service string, sourceArn string, actions Value, resources Value) {
+	merged := map[string]string{"Service": service}
+	for key, val := range params {
+		merged[key] = val
+	}
+
+	condition := StringEquals("aws:SourceAccount", "${AWS::AccountId}")
+	if len(sourceArn) > 0 {
+		merged["SourceArn"] = sourceArn
+		condition = condition.mustMerge(ArnLike("aws:SourceArn", "${SourceArn}"))
+	}
+
+	f.addPolicy(name, merged, Statement{
+		Effect:    EffectAllow,
+		Principal: Principal{"Service": Value{servicePrincipal("${Service}")}},
+		Action:    actions,
+		Resource:  resources,
+		Condition: condition,
+	})
+}
+
+// servicePrincipal returns the service principal of the AWS service. Service
+// principals end in amazonaws.com in every partition, including aws-cn,
+// whose URL suffix is amazonaws.com.cn.
+func servicePrincipal(service string) string {
+	return fmt.Sprintf("%s.amazonaws.com", service)
+}
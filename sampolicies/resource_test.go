@@ -0,0 +1,26 @@
+package sampolicies
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddServiceAccessPartitions(t *testing.T) {
+	for _, region := range []string{"us-east-1", "cn-north-1"} {
+		f := NewFactory().WithKind(ResourcePolicy).WithAccountID("123456789012").WithRegion(region)
+		f.AddQueueServiceAccess("jobs", ServiceSNS, "")
+
+		doc, err := f.GetPolicyDocument()
+		if err != nil {
+			t.Fatalf("%s: %s", region, err)
+		}
+
+		want := Principal{"Service": Value{"sns.amazonaws.com"}}
+		if got := doc.Statement[0].Principal; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got principal %v, want %v", region, got, want)
+		}
+		if got := doc.Statement[0].Resource[0]; got != "arn:"+PartitionForRegion(region)+":sqs:"+region+":123456789012:jobs" {
+			t.Errorf("%s: got resource %s", region, got)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package sampolicies
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// TemplateMatch is a template that grants an action, as found by
+// FindTemplates.
+type TemplateMatch struct {
+	Template Template
+
+	// Statement is the statement of the template that grants the action
+	Statement Statement
+
+	// WildcardResource is true if the statement grants the action on all
+	// resources
+	WildcardResource bool
+
+	// WildcardAction is true if the statement grants the action through a
+	// wildcard, like kinesis:Get*
+	WildcardAction bool
+
+	// Actions is the number of actions the template grants, with wildcards
+	// expanded using the action catalog
+	Actions int
+}
+
+// Usage returns the method call that adds the template to a Factory, like
+// AddKinesisStreamReadPolicy(streamName), or the call to Add for a custom
+// template.
+func (m TemplateMatch) Usage() string {
+	params := make([]string, len(m.Template.Parameters))
+
+	if _, ok := registry[m.Template.Name]; !ok {
+		for idx, p := range m.Template.Parameters {
+			params[idx] = fmt.Sprintf("%q: %s", p.Name, parameterName(p.Name))
+		}
+		return fmt.Sprintf("Add(%q, map[string]string{%s})", m.Template.Name, strings.Join(params, ", "))
+	}
+
+	for idx, p := range m.Template.Parameters {
+		params[idx] = parameterName(p.Name)
+	}
+	return fmt.Sprintf("Add%s(%s)", m.Template.Name, strings.Join(params, ", "))
+}
+
+// FindTemplates returns the AWS SAM policy templates that grant the action,
+// like FindTemplates of a Factory.
+func FindTemplates(action string, resource string) []TemplateMatch {
+	return NewFactory().FindTemplates(action, resource)
+}
+
+// FindTemplates returns the templates that grant the action, including the
+// custom templates of the factory. If resource isn't empty, only templates
+// that can grant the action on that ARN are returned, assuming the template
+// parameters are set to match it. The templates are ranked from the most to
+// the least narrow: templates that are limited to specific resources come
+// before templates that grant the action on all resources, templates that
+// name the action come before templates that grant it through a wildcard,
+// and templates that grant fewer actions come first.
+func (f *Factory) FindTemplates(action string, resource string) []TemplateMatch {
+	matches := make([]TemplateMatch, 0)
+
+	for _, t := range f.Templates() {
+		for _, s := range t.Statements {
+			if s.Effect != EffectAllow || !s.grants(action) {
+				continue
+			}
+			if len(resource) > 0 && !s.grantsResource(resource) {
+				continue
+			}
+
+			matches = append(matches, TemplateMatch{
+				Template:         t,
+				Statement:        s,
+				WildcardResource: s.Resource.contains("*") || len(s.NotResource) > 0,
+				WildcardAction:   !s.namesAction(action),
+				Actions:          templateActions(t),
+			})
+			break
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.WildcardResource != b.WildcardResource {
+			return !a.WildcardResource
+		}
+		if a.WildcardAction != b.WildcardAction {
+			return !a.WildcardAction
+		}
+		return a.Actions < b.Actions
+	})
+	return matches
+}
+
+// grantsResource returns true if the Resource or NotResource element of the
+// statement can cover the ARN. The placeholders of template parameters and
+// pseudo parameters match any value.
+func (s Statement) grantsResource(resource string) bool {
+	matches := func(patterns Value) bool {
+		for _, pattern := range patterns {
+			pattern = placeholderRegexp.ReplaceAllString(pattern, "*")
+			if wildcardRegexp(pattern, false).MatchString(resource) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(s.NotResource) > 0 {
+		return !matches(s.NotResource)
+	}
+	return matches(s.Resource)
+}
+
+// namesAction returns true if the Action element of the statement has the
+// action without a wildcard
+func (s Statement) namesAction(action string) bool {
+	for _, a := range s.Action {
+		if strings.EqualFold(a, action) {
+			return true
+		}
+	}
+	return false
+}
+
+// templateActions returns the number of actions the Allow statements of the
+// template grant. Actions of services that aren't in the action catalog
+// count as one action.
+func templateActions(t Template) int {
+	doc := PolicyDocument{Statement: t.Statements}
+
+	count := 0
+	for _, levels := range doc.AccessSummary() {
+		for _, actions := range levels {
+			count += len(actions)
+		}
+	}
+	return count
+}
+
+// parameterName returns the name of the method argument for a template
+// parameter, the way getParameterName of the policy generator names it. A
+// test of the generator keeps the two the same.
+func parameterName(name string) string {
+	name = strings.ToLower(name[:1]) + name[1:]
+	if token.IsKeyword(name) {
+		name = name + "Name"
+	}
+	return name
+}
@@ -0,0 +1,97 @@
+package sampolicies
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestFindTemplatesRanking(t *testing.T) {
+	queueArn := "arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${QueueName}"
+	queue := func(name string, resource string, actions ...string) Template {
+		tmpl := Template{
+			Name: name,
+			Statements: []Statement{{
+				Effect:   EffectAllow,
+				Action:   Value(actions),
+				Resource: Value{resource},
+			}},
+		}
+		if resource != "*" {
+			tmpl.Parameters = []Parameter{{Name: "QueueName"}}
+		}
+		return tmpl
+	}
+
+	f := NewFactory()
+	for _, tmpl := range []Template{
+		// Registered from the widest to the narrowest, so only the ranking
+		// can put them in the expected order
+		queue("SearchAllQueuesPolicy", "*", "sqs:ListQueueTags"),
+		queue("SearchWildcardActionPolicy", queueArn, "sqs:ListQueue*"),
+		queue("SearchTagsAdminPolicy", queueArn, "sqs:ListQueueTags", "sqs:TagQueue", "sqs:UntagQueue"),
+		queue("SearchTagsReadPolicy", queueArn, "sqs:ListQueueTags"),
+	} {
+		if err := f.RegisterTemplate(tmpl); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		resource string
+		want     []string
+	}{
+		{
+			name: "any resource",
+			want: []string{"SearchTagsReadPolicy", "SearchTagsAdminPolicy", "SearchWildcardActionPolicy", "SearchAllQueuesPolicy"},
+		},
+		{
+			name:     "queue in another partition",
+			resource: "arn:aws-cn:sqs:cn-north-1:123456789012:jobs",
+			want:     []string{"SearchTagsReadPolicy", "SearchTagsAdminPolicy", "SearchWildcardActionPolicy", "SearchAllQueuesPolicy"},
+		},
+		{
+			name:     "resource of another service",
+			resource: "arn:aws:sns:us-east-1:123456789012:jobs",
+			want:     []string{"SearchAllQueuesPolicy"},
+		},
+	}
+
+	for _, tt := range tests {
+		names := make([]string, 0)
+		for _, m := range f.FindTemplates("sqs:ListQueueTags", tt.resource) {
+			if strings.HasPrefix(m.Template.Name, "Search") {
+				names = append(names, m.Template.Name)
+			}
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, names, tt.want)
+		}
+	}
+}
+
+func TestGrantsResource(t *testing.T) {
+	bucketObjects := Value{"arn:${AWS::Partition}:s3:::${BucketName}/*"}
+
+	tests := []struct {
+		name      string
+		statement Statement
+		resource  string
+		want      bool
+	}{
+		{"placeholders match any value", Statement{Resource: Value{"arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${QueueName}"}}, "arn:aws-us-gov:sqs:us-gov-west-1:123456789012:jobs", true},
+		{"literal parts must match", Statement{Resource: Value{"arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${QueueName}"}}, "arn:aws:sns:us-east-1:123456789012:jobs", false},
+		{"object pattern matches an object", Statement{Resource: bucketObjects}, "arn:aws:s3:::assets/index.html", true},
+		{"object pattern doesn't match the bucket", Statement{Resource: bucketObjects}, "arn:aws:s3:::assets", false},
+		{"wildcard resource", Statement{Resource: Value{"*"}}, "arn:aws:s3:::assets", true},
+		{"excluded by NotResource", Statement{NotResource: bucketObjects}, "arn:aws:s3:::assets/index.html", false},
+		{"not excluded by NotResource", Statement{NotResource: bucketObjects}, "arn:aws:s3:::assets", true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.statement.grantsResource(tt.resource); got != tt.want {
+			t.Errorf("%s: got %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package sampolicies
+
+import (
+	"fmt"
+	"sort"
+	"unicode/utf8"
+)
+
+const (
+	// InlinePolicyMaxSize is the maximum number of characters of an inline role policy
+	InlinePolicyMaxSize = 10240
+
+	// ManagedPolicyMaxSize is the maximum number of characters of a managed policy
+	ManagedPolicyMaxSize = 6144
+
+	// TrustPolicyMaxSize is the default maximum number of characters of the trust policy of a role
+	TrustPolicyMaxSize = 2048
+
+	// BucketPolicyMaxSize is the maximum number of characters of an S3 bucket policy
+	BucketPolicyMaxSize = 20480
+)
+
+const (
+	// PolicyTooLargeErr is returned when the policy document has more characters than allowed
+	PolicyTooLargeErr = "policy has %d characters, which exceeds the %s of %d characters"
+
+	// StatementTooLargeErr is returned when a single statement doesn't fit in a policy of the requested size
+	StatementTooLargeErr = "statement %d has %d characters, which doesn't fit in a policy of %d characters"
+)
+
+// PolicySize returns the number of characters of the minified policy
+// document, which is what AWS counts against the size limits.
+func (f *Factory) PolicySize() (int, error) {
+	doc, err := f.GetPolicyDocument()
+	if err != nil {
+		return 0, err
+	}
+	return documentSize(doc)
+}
+
+// CheckPolicySize returns an error if the minified policy document has more
+// characters than maxSize. Use one of the ...MaxSize constants to check
+// against the AWS limits, in which case the error names the limit. A maxSize
+// of 0 checks against the limit of the kind of policy the factory builds.
+func (f *Factory) CheckPolicySize(maxSize int) error {
+	size, err := f.PolicySize()
+	if err != nil {
+		return err
+	}
+	if maxSize <= 0 {
+		maxSize = f.Kind().MaxSize()
+	}
+	if size > maxSize {
+		return fmt.Errorf(PolicyTooLargeErr, size, limitName(maxSize), maxSize)
+	}
+	return nil
+}
+
+// GetPolicyStatements is like GetPolicyStatement, but splits the statements
+// over as many policy documents as needed for each of them to have at most
+// maxSize characters. This allows a role to get all statements through
+// multiple managed policies. The statements keep their order within each
+// document. A maxSize of 0, or one above the size limit of the kind of
+// policy the factory builds, uses that limit. An error is returned if a
+// single statement doesn't fit in maxSize.
+func (f *Factory) GetPolicyStatements(maxSize int) ([]string, error) {
+	doc, err := f.GetPolicyDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	if limit := f.Kind().MaxSize(); maxSize <= 0 || maxSize > limit {
+		maxSize = limit
+	}
+
+	overhead, err := documentSize(&PolicyDocument{Version: doc.Version, Statement: []Statement{}})
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make([]int, len(doc.Statement))
+	for idx := range doc.Statement {
+		sizes[idx], err = statementSize(doc.Statement[idx])
+		if err != nil {
+			return nil, err
+		}
+		if overhead+sizes[idx] > maxSize {
+			return nil, fmt.Errorf(StatementTooLargeErr, idx, sizes[idx], maxSize)
+		}
+	}
+
+	// Place the largest statements first, each in the first document it fits
+	// in, which keeps the number of documents low
+	order := make([]int, len(sizes))
+	for idx := range order {
+		order[idx] = idx
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return sizes[order[i]] > sizes[order[j]]
+	})
+
+	bins := make([][]int, 0)
+	binSizes := make([]int, 0)
+	for _, idx := range order {
+		placed := false
+		for b := range bins {
+			// Every statement after the first one adds a comma
+			if binSizes[b]+1+sizes[idx] <= maxSize {
+				bins[b] = append(bins[b], idx)
+				binSizes[b] += 1 + sizes[idx]
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			bins = append(bins, []int{idx})
+			binSizes = append(binSizes, overhead+sizes[idx])
+		}
+	}
+
+	policies := make([]string, len(bins))
+	for b := range bins {
+		sort.Ints(bins[b])
+
+		part := &PolicyDocument{Version: doc.Version, Statement: make([]Statement, len(bins[b]))}
+		for i, idx := range bins[b] {
+			part.Statement[i] = doc.Statement[idx]
+		}
+
+		policy, err := part.JSON()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := ValidatePolicyDocumentKind(policy, f.Kind()); err != nil {
+			return nil, err
+		}
+		policies[b] = policy
+	}
+
+	return policies, nil
+}
+
+// documentSize returns the number of characters of the minified document
+func documentSize(doc *PolicyDocument) (int, error) {
+	policy, err := doc.JSON()
+	if err != nil {
+		return 0, err
+	}
+	return utf8.RuneCountInString(policy), nil
+}
+
+// statementSize returns the number of characters of the minified statement
+func statementSize(s Statement) (int, error) {
+	b, err := marshalJSON(s)
+	if err != nil {
+		return 0, err
+	}
+	return utf8.RuneCount(b), nil
+}
+
+// limitName returns the name of the AWS limit for the size
+func limitName(maxSize int) string {
+	switch maxSize {
+	case InlinePolicyMaxSize:
+		return "inline role policy limit"
+	case ManagedPolicyMaxSize:
+		return "managed policy limit"
+	case TrustPolicyMaxSize:
+		return "trust policy limit"
+	case BucketPolicyMaxSize:
+		return "bucket policy limit"
+	}
+	return "maximum size"
+}
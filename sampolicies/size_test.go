@@ -0,0 +1,104 @@
+package sampolicies
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGetPolicyStatementSizeLimit(t *testing.T) {
+	tests := []struct {
+		kind  PolicyKind
+		limit string
+	}{
+		{IdentityPolicy, ""},
+		{ResourcePolicy, ""},
+		{TrustPolicy, "trust policy limit"},
+	}
+
+	for _, tt := range tests {
+		_, err := sizedFactory(tt.kind, 40).GetPolicyStatement()
+		switch {
+		case len(tt.limit) == 0 && err != nil:
+			t.Errorf("%s: unexpected error %s", tt.kind, err)
+		case len(tt.limit) > 0 && (err == nil || !strings.Contains(err.Error(), tt.limit)):
+			t.Errorf("%s: got %v, want an error naming the %s", tt.kind, err, tt.limit)
+		}
+	}
+}
+
+func TestGetPolicyStatementsSizeLimit(t *testing.T) {
+	// A trust policy is split at the trust policy limit, even if a larger
+	// size is requested
+	for _, maxSize := range []int{0, InlinePolicyMaxSize} {
+		policies, err := sizedFactory(TrustPolicy, 40).GetPolicyStatements(maxSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(policies) < 2 {
+			t.Errorf("%d: got %d policies, want at least 2", maxSize, len(policies))
+		}
+		for _, policy := range policies {
+			if size := utf8.RuneCountInString(policy); size > TrustPolicyMaxSize {
+				t.Errorf("%d: got a policy of %d characters", maxSize, size)
+			}
+		}
+	}
+
+	// A smaller size than the limit of the kind is kept
+	policies, err := sizedFactory(IdentityPolicy, 40).GetPolicyStatements(1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, policy := range policies {
+		if size := utf8.RuneCountInString(policy); size > 1024 {
+			t.Errorf("got a policy of %d characters", size)
+		}
+	}
+}
+
+func TestPolicySizeWithoutHTMLEscaping(t *testing.T) {
+	statement := func(prefix string) Statement {
+		return Statement{
+			Effect:    EffectAllow,
+			Action:    Value{"s3:ListBucket"},
+			Resource:  Value{"arn:aws:s3:::uploads"},
+			Condition: StringLike("s3:prefix", prefix),
+		}
+	}
+
+	// a&b, a<b, and a>b have as many characters as abc
+	want, err := statementSize(statement("abc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, prefix := range []string{"a&b", "a<b", "a>b"} {
+		size, err := statementSize(statement(prefix))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size != want {
+			t.Errorf("%s: got a statement of %d characters, want %d", prefix, size, want)
+		}
+
+		f := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1")
+		f.AddStatements(statement(prefix))
+
+		policy, err := f.GetPolicyStatement()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(policy, `"`+prefix+`"`) {
+			t.Errorf("%s: policy escapes the prefix: %s", prefix, policy)
+		}
+
+		size, err = f.PolicySize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if size != utf8.RuneCountInString(policy) {
+			t.Errorf("%s: got a size of %d for a policy of %d characters", prefix, size, utf8.RuneCountInString(policy))
+		}
+	}
+}
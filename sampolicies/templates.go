@@ -0,0 +1,251 @@
+package sampolicies
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// UnknownTemplateErr is returned when a template is added that doesn't exist
+	UnknownTemplateErr = "unknown policy template %s"
+
+	// MissingParameterErr is returned when a template is added without one of its parameters
+	MissingParameterErr = "policy template %s is missing parameter %s"
+
+	// UnknownParameterErr is returned when a template is added with a parameter it doesn't have
+	UnknownParameterErr = "policy template %s has no parameter %s"
+
+	// DuplicateTemplateErr is returned when a template is registered with a name that already exists
+	DuplicateTemplateErr = "policy template %s already exists"
+
+	// InvalidTemplateErr is returned when a template is registered that isn't valid
+	InvalidTemplateErr = "policy template %s is invalid: %s"
+)
+
+// Template is a named set of statements that can be added to a Factory.
+// The statements use ${ParameterName} placeholders for the parameters of
+// the template.
+type Template struct {
+	Name        string
+	Description string
+	Parameters  []Parameter
+	Statements  []Statement
+}
+
+// Parameter is a parameter of a Template that must be set when the template
+// is added to a Factory.
+type Parameter struct {
+	Name        string
+	Description string
+}
+
+// registry holds the AWS SAM policy templates by name
+var registry = newRegistry(samTemplates)
+
+// nameRegexp matches the names of templates and parameters
+var nameRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*$`)
+
+func newRegistry(templates []Template) map[string]Template {
+	r := make(map[string]Template, len(templates))
+	for _, t := range templates {
+		r[t.Name] = t
+	}
+	return r
+}
+
+// Templates returns the templates that can be added to the factory, sorted
+// by name.
+func (f *Factory) Templates() []Template {
+	return listTemplates(f.templates)
+}
+
+// Add adds the template with the given name to the factory. The params map
+// has a value for every parameter of the template, keyed by the name of the
+// parameter (like TableName). An error is returned if the template doesn't
+// exist, or if a parameter is missing or unknown.
+func (f *Factory) Add(name string, params map[string]string) error {
+	return f.AddWithCondition(name, params, nil)
+}
+
+// RegisterTemplate registers a custom template with the factory, after which
+// it can be added with Add just like the AWS SAM policy templates. The
+// statements of the template can use a ${ParameterName} placeholder for
+// each of its parameters, the AWS pseudo parameters (like ${AWS::Partition}
+// and ${AWS::URLSuffix}), the custom variables set on the factory so far,
+// and IAM policy variables like ${aws:username}. An error is returned if the
+// name is already taken, if a placeholder isn't a parameter of the template,
+// if a parameter isn't used, or if the statements don't follow the IAM policy
+// grammar.
+func (f *Factory) RegisterTemplate(t Template) error {
+	if _, ok := f.template(t.Name); ok {
+		return fmt.Errorf(DuplicateTemplateErr, t.Name)
+	}
+
+	variables := make([]string, 0, len(f.variables))
+	for name := range f.variables {
+		variables = append(variables, name)
+	}
+	if err := t.validate(variables); err != nil {
+		return err
+	}
+
+	if f.templates == nil {
+		f.templates = make(map[string]Template)
+	}
+	f.templates[t.Name] = t.copy()
+
+	return nil
+}
+
+// template returns the custom or AWS SAM policy template with the given name
+func (f *Factory) template(name string) (Template, bool) {
+	return findTemplate(f.templates, name)
+}
+
+// mustAdd is like Add but panics if an error is returned. It is used by the
+// generated methods, which always pass the right parameters.
+func (f *Factory) mustAdd(name string, params map[string]string) {
+	if err := f.Add(name, params); err != nil {
+		panic(err)
+	}
+}
+
+// copy returns a deep copy of the template so the registry can't be
+// modified through the templates that are returned.
+func (t Template) copy() Template {
+	out := t
+	out.Parameters = append([]Parameter(nil), t.Parameters...)
+	out.Statements = make([]Statement, len(t.Statements))
+	for idx := range t.Statements {
+		out.Statements[idx] = t.Statements[idx].copy()
+	}
+	return out
+}
+
+// listTemplates returns copies of the AWS SAM policy templates and the custom
+// templates, sorted by name.
+func listTemplates(custom map[string]Template) []Template {
+	templates := make([]Template, 0, len(registry)+len(custom))
+	for _, t := range registry {
+		templates = append(templates, t.copy())
+	}
+	for _, t := range custom {
+		templates = append(templates, t.copy())
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+
+	return templates
+}
+
+// findTemplate returns the custom or AWS SAM policy template with the given
+// name. Custom templates are looked up first.
+func findTemplate(custom map[string]Template, name string) (Template, bool) {
+	if t, ok := custom[name]; ok {
+		return t, true
+	}
+	t, ok := registry[name]
+	return t, ok
+}
+
+// checkParameters makes sure the names are exactly the parameters of the
+// template.
+func (t Template) checkParameters(names []string) error {
+	given := make(map[string]bool, len(names))
+	for _, name := range names {
+		given[name] = true
+	}
+
+	known := make(map[string]bool, len(t.Parameters))
+	for _, p := range t.Parameters {
+		known[p.Name] = true
+		if !given[p.Name] {
+			return fmt.Errorf(MissingParameterErr, t.Name, p.Name)
+		}
+	}
+
+	unknown := make([]string, 0)
+	for _, name := range names {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf(UnknownParameterErr, t.Name, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// validate checks a custom template before it's registered. The statements
+// can use the parameters of the template, the AWS pseudo parameters, the
+// given custom variables, and IAM policy variables.
+func (t Template) validate(variables []string) error {
+	if !nameRegexp.MatchString(t.Name) {
+		return fmt.Errorf(InvalidTemplateErr, t.Name, "name must be alphanumeric and start with a letter")
+	}
+
+	if len(t.Statements) == 0 {
+		return fmt.Errorf(InvalidTemplateErr, t.Name, "template has no statements")
+	}
+
+	params := make(map[string]bool, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !nameRegexp.MatchString(p.Name) {
+			return fmt.Errorf(InvalidTemplateErr, t.Name, fmt.Sprintf("parameter name %q must be alphanumeric and start with a letter", p.Name))
+		}
+		if params[p.Name] {
+			return fmt.Errorf(InvalidTemplateErr, t.Name, fmt.Sprintf("parameter %s is defined more than once", p.Name))
+		}
+		params[p.Name] = true
+	}
+
+	isVariable := make(map[string]bool, len(variables))
+	for _, name := range variables {
+		isVariable[name] = true
+	}
+
+	used := make(map[string]bool, len(params))
+	for _, name := range placeholders(t.Statements) {
+		switch {
+		case params[name]:
+			used[name] = true
+		case pseudoParameters[name], isVariable[name], isPolicyVariable(name):
+		default:
+			return fmt.Errorf(InvalidTemplateErr, t.Name, fmt.Sprintf("placeholder ${%s} is not a parameter of the template", name))
+		}
+	}
+
+	for _, p := range t.Parameters {
+		if !used[p.Name] {
+			return fmt.Errorf(InvalidTemplateErr, t.Name, fmt.Sprintf("parameter %s is not used in the statements", p.Name))
+		}
+	}
+
+	// Check the grammar with a sample value for every placeholder
+	values := make(map[string]string, len(params)+len(pseudoParameters)+len(variables))
+	for _, name := range variables {
+		values[name] = name
+	}
+	for name := range pseudoParameters {
+		values[name] = name
+	}
+	for name := range params {
+		values[name] = name
+	}
+
+	statements := make([]Statement, len(t.Statements))
+	for idx := range t.Statements {
+		statements[idx], _ = substitute(t.Statements[idx], values)
+	}
+
+	if err := validateStatements(statements, ""); err != nil {
+		return fmt.Errorf(InvalidTemplateErr, t.Name, err.Error())
+	}
+
+	return nil
+}
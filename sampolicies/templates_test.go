@@ -0,0 +1,109 @@
+package sampolicies
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegisterTemplateRejectsInvalidTemplates(t *testing.T) {
+	// prefixTemplate returns a valid template that grants read access to a
+	// prefix of a bucket
+	prefixTemplate := func() Template {
+		return Template{
+			Name:       "S3PrefixReadPolicy",
+			Parameters: []Parameter{{Name: "BucketName"}, {Name: "Prefix"}},
+			Statements: []Statement{{
+				Effect:   EffectAllow,
+				Action:   Value{"s3:GetObject"},
+				Resource: Value{"arn:${AWS::Partition}:s3:::${BucketName}/${Prefix}/${aws:username}/*"},
+			}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Template)
+		err    string
+	}{
+		{"valid template", func(*Template) {}, ""},
+		{"name of a built-in template", func(t *Template) { t.Name = "S3ReadPolicy" }, "policy template S3ReadPolicy already exists"},
+		{"name with a dash", func(t *Template) { t.Name = "S3-Prefix" }, "name must be alphanumeric"},
+		{"no statements", func(t *Template) { t.Statements = nil }, "template has no statements"},
+		{"invalid parameter name", func(t *Template) { t.Parameters[1].Name = "1Prefix" }, `parameter name "1Prefix" must be alphanumeric`},
+		{"duplicate parameter", func(t *Template) { t.Parameters = append(t.Parameters, Parameter{Name: "Prefix"}) }, "parameter Prefix is defined more than once"},
+		{"unknown placeholder", func(t *Template) {
+			t.Statements[0].Resource = Value{"arn:${AWS::Partition}:s3:::${BucketName}/${Prefix}/${Folder}/*"}
+		}, "placeholder ${Folder} is not a parameter of the template"},
+		{"unused parameter", func(t *Template) { t.Parameters = append(t.Parameters, Parameter{Name: "Folder"}) }, "parameter Folder is not used in the statements"},
+		{"invalid Effect", func(t *Template) { t.Statements[0].Effect = "Permit" }, `has invalid Effect "Permit"`},
+		{"missing Resource", func(t *Template) {
+			t.Statements[0].Resource = nil
+			t.Statements[0].Condition = StringLike("s3:prefix", "${BucketName}/${Prefix}/*")
+		}, "is missing Resource or NotResource"},
+		{"Action and NotAction", func(t *Template) { t.Statements[0].NotAction = Value{"s3:PutObject"} }, "has both Action and NotAction"},
+		{"invalid action", func(t *Template) { t.Statements[0].Action = Value{"GetObject"} }, `has invalid Action "GetObject"`},
+		{"invalid condition operator", func(t *Template) {
+			t.Statements[0].Condition = Condition{"StringEqual": {"s3:prefix": Value{"${Prefix}"}}}
+		}, "StringEqual is not a condition operator"},
+	}
+
+	for _, tt := range tests {
+		tmpl := prefixTemplate()
+		tt.modify(&tmpl)
+
+		f := NewFactory()
+		err := f.RegisterTemplate(tmpl)
+		if len(tt.err) == 0 {
+			if err != nil {
+				t.Errorf("%s: %s", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: got error %v, want %s", tt.name, err, tt.err)
+			continue
+		}
+		if _, ok := f.templates[tmpl.Name]; ok {
+			t.Errorf("%s: invalid template was registered", tt.name)
+		}
+	}
+}
+
+func TestRegisterTemplateTwice(t *testing.T) {
+	tmpl := Template{
+		Name:       "AppConfigReadPolicy",
+		Parameters: []Parameter{{Name: "ApplicationId"}},
+		Statements: []Statement{{
+			Effect:   EffectAllow,
+			Action:   Value{"appconfig:GetConfiguration"},
+			Resource: Value{"arn:${AWS::Partition}:appconfig:${AWS::Region}:${AWS::AccountId}:application/${ApplicationId}/*"},
+		}},
+	}
+
+	f := NewFactory()
+	if err := f.RegisterTemplate(tmpl); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.RegisterTemplate(tmpl); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("got error %v, want an error for a duplicate template", err)
+	}
+}
+
+func TestRegisterTemplateAllowsVariables(t *testing.T) {
+	tmpl := Template{
+		Name: "EnvironmentQueuePolicy",
+		Statements: []Statement{{
+			Effect:   EffectAllow,
+			Action:   Value{"sqs:SendMessage"},
+			Resource: Value{"arn:${AWS::Partition}:sqs:${AWS::Region}:${AWS::AccountId}:${Environment}-jobs"},
+		}},
+	}
+
+	if err := NewFactory().RegisterTemplate(tmpl); err == nil || !strings.Contains(err.Error(), "placeholder ${Environment}") {
+		t.Errorf("got error %v, want an error for an unknown placeholder", err)
+	}
+
+	if err := NewFactory().WithVariable("Environment", "prod").RegisterTemplate(tmpl); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,128 @@
+package sampolicies
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	// UnknownPartitionErr is returned when a partition-specific value is needed for a partition the factory doesn't know
+	UnknownPartitionErr = "unknown partition %s"
+)
+
+// The names of the AWS services that commonly assume a role. The service
+// principal is the name followed by amazonaws.com, like lambda.amazonaws.com.
+const (
+	ServiceLambda        = "lambda"
+	ServiceEdgeLambda    = "edgelambda"
+	ServiceStepFunctions = "states"
+	ServiceECSTasks      = "ecs-tasks"
+	ServiceAPIGateway    = "apigateway"
+	ServiceEventBridge   = "events"
+)
+
+// samlAudiences are the SAML sign-in endpoints of the AWS partitions
+var samlAudiences = map[string]string{
+	"aws":        "https://signin.aws.amazon.com/saml",
+	"aws-cn":     "https://signin.amazonaws.cn/saml",
+	"aws-us-gov": "https://signin.amazonaws-us-gov.com/saml",
+}
+
+// AddAssumeRoleService allows the AWS services to assume the role, like
+// ServiceStepFunctions or ServiceECSTasks. The service principals are the
+// same in every partition. It can only be used in a factory that builds a
+// TrustPolicy.
+func (f *Factory) AddAssumeRoleService(services ...string) {
+	principals := make(Value, len(services))
+	for idx, service := range services {
+		principals[idx] = servicePrincipal(service)
+	}
+
+	f.addPolicy("AssumeRoleService", nil, Statement{
+		Effect:    EffectAllow,
+		Principal: Principal{"Service": principals},
+		Action:    Value{"sts:AssumeRole"},
+	})
+}
+
+// AddAssumeRoleAccount allows principals in another AWS account to assume
+// the role. If externalID isn't empty, the caller must pass it as the
+// sts:ExternalId, which protects against the confused deputy problem when
+// the account belongs to a third party. If requireMFA is set, the caller
+// must have signed in with MFA. It can only be used in a factory that builds
+// a TrustPolicy.
+func (f *Factory) AddAssumeRoleAccount(accountID string, externalID string, requireMFA bool) {
+	params := map[string]string{"AccountId": accountID}
+
+	var condition Condition
+	if len(externalID) > 0 {
+		params["ExternalId"] = externalID
+		condition = condition.mustMerge(StringEquals("sts:ExternalId", "${ExternalId}"))
+	}
+	if requireMFA {
+		condition = condition.mustMerge(Bool("aws:MultiFactorAuthPresent", true))
+	}
+
+	f.addPolicy("AssumeRoleAccount", params, Statement{
+		Effect:    EffectAllow,
+		Principal: Principal{"AWS": Value{"arn:${AWS::Partition}:iam::${AccountId}:root"}},
+		Action:    Value{"sts:AssumeRole"},
+		Condition: condition,
+	})
+}
+
+// AddAssumeRoleWebIdentity allows users authenticated by an OIDC identity
+// provider, like the provider of a CI system, to assume the role. The
+// provider must be registered in the account with the providerURL (like
+// token.actions.githubusercontent.com). The token must have the audience,
+// and a subject that matches one of the subjects, which can contain
+// wildcards. It can only be used in a factory that builds a TrustPolicy.
+func (f *Factory) AddAssumeRoleWebIdentity(providerURL string, audience string, subjects ...string) {
+	params := map[string]string{
+		"Provider": strings.TrimSuffix(strings.TrimPrefix(providerURL, "https://"), "/"),
+		"Audience": audience,
+	}
+
+	condition := StringEquals("${Provider}:aud", "${Audience}")
+	if len(subjects) > 0 {
+		values := make([]string, len(subjects))
+		for idx := range subjects {
+			name := fmt.Sprintf("Subject%d", idx)
+			params[name] = subjects[idx]
+			values[idx] = fmt.Sprintf("${%s}", name)
+		}
+		condition = condition.mustMerge(StringLike("${Provider}:sub", values...))
+	}
+
+	f.addPolicy("AssumeRoleWebIdentity", params, Statement{
+		Effect:    EffectAllow,
+		Principal: Principal{"Federated": Value{"arn:${AWS::Partition}:iam::${AWS::AccountId}:oidc-provider/${Provider}"}},
+		Action:    Value{"sts:AssumeRoleWithWebIdentity"},
+		Condition: condition,
+	})
+}
+
+// AddAssumeRoleSAML allows users federated through the SAML provider with the
+// given name to assume the role, when they sign in through the AWS Management
+// Console of the partition. The partition, or a region to infer it from, must
+// be set before the policy is added, as the sign-in endpoint differs per
+// partition. It can only be used in a factory that builds a TrustPolicy.
+func (f *Factory) AddAssumeRoleSAML(providerName string) error {
+	if len(f.Partition()) == 0 {
+		return errors.New(PartitionMissingErr)
+	}
+
+	audience, ok := samlAudiences[f.Partition()]
+	if !ok {
+		return fmt.Errorf(UnknownPartitionErr, f.Partition())
+	}
+
+	f.addPolicy("AssumeRoleSAML", map[string]string{"ProviderName": providerName}, Statement{
+		Effect:    EffectAllow,
+		Principal: Principal{"Federated": Value{"arn:${AWS::Partition}:iam::${AWS::AccountId}:saml-provider/${ProviderName}"}},
+		Action:    Value{"sts:AssumeRoleWithSAML"},
+		Condition: StringEquals("SAML:aud", audience),
+	})
+	return nil
+}
@@ -0,0 +1,39 @@
+package sampolicies
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddAssumeRoleServicePartitions(t *testing.T) {
+	for _, region := range []string{"us-east-1", "cn-north-1", "us-gov-west-1"} {
+		f := NewFactory().WithKind(TrustPolicy).WithAccountID("123456789012").WithRegion(region)
+		f.AddAssumeRoleService(ServiceLambda, ServiceStepFunctions)
+
+		doc, err := f.GetPolicyDocument()
+		if err != nil {
+			t.Fatalf("%s: %s", region, err)
+		}
+
+		want := Value{"lambda.amazonaws.com", "states.amazonaws.com"}
+		if got := doc.Statement[0].Principal["Service"]; !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got service principals %v, want %v", region, got, want)
+		}
+	}
+}
+
+func TestAddAssumeRoleLambdaMatchesService(t *testing.T) {
+	lambda := NewFactory().WithKind(TrustPolicy).WithAccountID("123456789012").WithRegion("cn-north-1")
+	lambda.AddAssumeRoleLambda()
+
+	service := NewFactory().WithKind(TrustPolicy).WithAccountID("123456789012").WithRegion("cn-north-1")
+	service.AddAssumeRoleService(ServiceLambda)
+
+	diff, err := lambda.Diff(service)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Empty() {
+		t.Errorf("AddAssumeRoleLambda and AddAssumeRoleService(ServiceLambda) differ:\n%s", diff)
+	}
+}
@@ -0,0 +1,314 @@
+package sampolicies
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const (
+	// InvalidPolicyErr is returned when a policy added to the factory doesn't follow the IAM policy grammar
+	InvalidPolicyErr = "policy %s is invalid: %s"
+
+	// InvalidDocumentErr is returned when a policy document doesn't follow the IAM policy grammar
+	InvalidDocumentErr = "invalid policy document: %s"
+)
+
+var (
+	// documentKeys are the elements allowed at the top level of a policy document
+	documentKeys = map[string]bool{"Version": true, "Id": true, "Statement": true}
+
+	// statementKeys are the elements allowed in a policy statement
+	statementKeys = map[string]bool{
+		"Sid": true, "Effect": true, "Principal": true, "NotPrincipal": true, "Action": true,
+		"NotAction": true, "Resource": true, "NotResource": true, "Condition": true,
+	}
+
+	// policyVersions are the versions of the IAM policy language
+	policyVersions = map[string]bool{"2012-10-17": true, "2008-10-17": true}
+
+	// actionRegexp matches a single action, like s3:GetObject or sqs:SendMessage*
+	actionRegexp = regexp.MustCompile(`^(\*|[a-zA-Z0-9-]+:[a-zA-Z0-9*?]+)$`)
+
+	// sidRegexp matches the characters allowed in a statement ID
+	sidRegexp = regexp.MustCompile(`^[a-zA-Z0-9]*$`)
+)
+
+// Validate checks that every policy added to the factory results in a valid
+// IAM policy document. The error names the policy that is invalid.
+func (f *Factory) Validate() error {
+	_, err := f.GetPolicyDocument()
+	return err
+}
+
+// ValidatePolicyDocument parses the policy document and checks it against
+// the IAM policy grammar. It checks the Version, that only known elements
+// are used, and that every statement has an Effect, an Action or NotAction,
+// and a Resource or NotResource.
+func ValidatePolicyDocument(policy string) error {
+	if err := validateDocument([]byte(policy), ""); err != nil {
+		return fmt.Errorf(InvalidDocumentErr, err.Error())
+	}
+	return nil
+}
+
+// validatePolicy checks the statements added by a single policy against the
+// kind of policy and returns an error naming the policy if they're invalid.
+func validatePolicy(name string, statements []Statement, kind PolicyKind) error {
+	if err := validateStatements(statements, kind); err != nil {
+		return fmt.Errorf(InvalidPolicyErr, name, err.Error())
+	}
+	return nil
+}
+
+// validateStatements checks the statements as if they were a complete
+// policy document of the kind. An empty kind allows all elements.
+func validateStatements(statements []Statement, kind PolicyKind) error {
+	b, err := marshalJSON(&PolicyDocument{Version: PolicyVersion, Statement: statements})
+	if err != nil {
+		return err
+	}
+	return validateDocument(b, kind)
+}
+
+func validateDocument(data []byte, kind PolicyKind) error {
+	var doc map[string]json.RawMessage
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("document is not a JSON object: %s", err.Error())
+	}
+
+	if err := checkKeys(doc, documentKeys); err != nil {
+		return fmt.Errorf("document %s", err.Error())
+	}
+
+	version, ok := doc["Version"]
+	if !ok {
+		return fmt.Errorf("document is missing the Version element")
+	}
+	var v string
+	if err := json.Unmarshal(version, &v); err != nil || !policyVersions[v] {
+		return fmt.Errorf("document has unknown Version %s", string(version))
+	}
+
+	raw, ok := doc["Statement"]
+	if !ok {
+		return fmt.Errorf("document is missing the Statement element")
+	}
+
+	// The Statement element is either a single statement or an array of statements
+	var statements []json.RawMessage
+	if err := json.Unmarshal(raw, &statements); err != nil {
+		statements = []json.RawMessage{raw}
+	}
+
+	if len(statements) == 0 {
+		return fmt.Errorf("document has no statements")
+	}
+
+	for idx := range statements {
+		if err := validateStatement(statements[idx], kind); err != nil {
+			return fmt.Errorf("statement %d %s", idx, err.Error())
+		}
+	}
+
+	return nil
+}
+
+func validateStatement(data json.RawMessage, kind PolicyKind) error {
+	var statement map[string]json.RawMessage
+	if err := json.Unmarshal(data, &statement); err != nil {
+		return fmt.Errorf("is not a JSON object: %s", err.Error())
+	}
+
+	if err := checkKeys(statement, statementKeys); err != nil {
+		return err
+	}
+
+	if raw, ok := statement["Sid"]; ok {
+		var sid string
+		if err := json.Unmarshal(raw, &sid); err != nil || !sidRegexp.MatchString(sid) {
+			return fmt.Errorf("has invalid Sid %s", string(raw))
+		}
+	}
+
+	if _, ok := statement["Effect"]; !ok {
+		return fmt.Errorf("is missing Effect")
+	}
+
+	var effect string
+	if err := json.Unmarshal(statement["Effect"], &effect); err != nil || (effect != EffectAllow && effect != EffectDeny) {
+		return fmt.Errorf("has invalid Effect %s, must be %s or %s", string(statement["Effect"]), EffectAllow, EffectDeny)
+	}
+
+	if err := checkExclusive(statement, "Principal", "NotPrincipal", false); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"Principal", "NotPrincipal"} {
+		if raw, ok := statement[key]; ok {
+			if err := checkPrincipal(raw); err != nil {
+				return fmt.Errorf("has invalid %s: %s", key, err.Error())
+			}
+		}
+	}
+
+	if err := checkExclusive(statement, "Action", "NotAction", true); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"Action", "NotAction"} {
+		if raw, ok := statement[key]; ok {
+			values, err := checkValue(raw)
+			if err != nil {
+				return fmt.Errorf("has invalid %s: %s", key, err.Error())
+			}
+			for _, action := range values {
+				if !actionRegexp.MatchString(action) {
+					return fmt.Errorf("has invalid %s %q", key, action)
+				}
+			}
+		}
+	}
+
+	// Statements with a principal are resource-based or trust policies, which
+	// don't always have a resource
+	_, hasPrincipal := statement["Principal"]
+	_, hasNotPrincipal := statement["NotPrincipal"]
+	if err := checkExclusive(statement, "Resource", "NotResource", !hasPrincipal && !hasNotPrincipal); err != nil {
+		return err
+	}
+
+	for _, key := range []string{"Resource", "NotResource"} {
+		if raw, ok := statement[key]; ok {
+			if _, err := checkValue(raw); err != nil {
+				return fmt.Errorf("has invalid %s: %s", key, err.Error())
+			}
+		}
+	}
+
+	if raw, ok := statement["Condition"]; ok {
+		if err := checkCondition(raw); err != nil {
+			return fmt.Errorf("has invalid Condition: %s", err.Error())
+		}
+	}
+
+	return checkKind(statement, kind)
+}
+
+// checkKeys returns an error listing the keys that aren't allowed
+func checkKeys(m map[string]json.RawMessage, allowed map[string]bool) error {
+	unknown := make([]string, 0)
+	for key := range m {
+		if !allowed[key] {
+			unknown = append(unknown, key)
+		}
+	}
+
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("has unknown elements %s", strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// checkExclusive makes sure at most one of the two elements is present and,
+// if required is set, that exactly one of them is.
+func checkExclusive(m map[string]json.RawMessage, key, notKey string, required bool) error {
+	_, hasKey := m[key]
+	_, hasNotKey := m[notKey]
+
+	if hasKey && hasNotKey {
+		return fmt.Errorf("has both %s and %s", key, notKey)
+	}
+
+	if required && !hasKey && !hasNotKey {
+		return fmt.Errorf("is missing %s or %s", key, notKey)
+	}
+	return nil
+}
+
+// checkValue makes sure the element is a non-empty string or a non-empty
+// array of non-empty strings and returns the strings.
+func checkValue(raw json.RawMessage) ([]string, error) {
+	var v Value
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return nil, fmt.Errorf("%s is not a string or an array of strings", string(raw))
+	}
+
+	if len(v) == 0 {
+		return nil, fmt.Errorf("is empty")
+	}
+
+	for idx := range v {
+		if len(v[idx]) == 0 {
+			return nil, fmt.Errorf("contains an empty string")
+		}
+	}
+	return v, nil
+}
+
+func checkPrincipal(raw json.RawMessage) error {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		if s != "*" {
+			return fmt.Errorf("%q must be \"*\" or an object", s)
+		}
+		return nil
+	}
+
+	var principal map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &principal); err != nil {
+		return fmt.Errorf("%s is not a string or an object", string(raw))
+	}
+
+	if len(principal) == 0 {
+		return fmt.Errorf("is empty")
+	}
+
+	for key, val := range principal {
+		if _, err := checkValue(val); err != nil {
+			return fmt.Errorf("%s %s", key, err.Error())
+		}
+	}
+	return nil
+}
+
+func checkCondition(raw json.RawMessage) error {
+	var condition map[string]map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &condition); err != nil {
+		return fmt.Errorf("%s is not an object of condition operators", string(raw))
+	}
+
+	for operator, keys := range condition {
+		if !isConditionOperator(operator) {
+			return fmt.Errorf("%s is not a condition operator", operator)
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("%s has no condition keys", operator)
+		}
+		for key, val := range keys {
+			var values []interface{}
+			if err := json.Unmarshal(val, &values); err != nil {
+				values = []interface{}{nil}
+				if err := json.Unmarshal(val, &values[0]); err != nil {
+					return fmt.Errorf("%s %s has an invalid value", operator, key)
+				}
+			}
+
+			if len(values) == 0 {
+				return fmt.Errorf("%s %s has no values", operator, key)
+			}
+
+			for idx := range values {
+				switch values[idx].(type) {
+				case string, bool, float64:
+				default:
+					return fmt.Errorf("%s %s has an invalid value %v", operator, key, values[idx])
+				}
+			}
+		}
+	}
+	return nil
+}
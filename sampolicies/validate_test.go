@@ -0,0 +1,76 @@
+package sampolicies
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidatePolicyDocument(t *testing.T) {
+	// document wraps a single statement in a policy document
+	document := func(statement string) string {
+		return `{"Version":"2012-10-17","Statement":[` + statement + `]}`
+	}
+
+	tests := []struct {
+		name   string
+		policy string
+		err    string
+	}{
+		{"valid statement", document(`{"Sid":"ReadObjects1","Effect":"Allow","Action":"s3:GetObject","Resource":"*"}`), ""},
+		{"single statement object", `{"Version":"2012-10-17","Statement":{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}}`, ""},
+		{"older version", `{"Version":"2008-10-17","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`, ""},
+		{"unknown version", `{"Version":"2012-10-18","Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`, "document has unknown Version"},
+		{"missing version", `{"Statement":[{"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}]}`, "document is missing the Version element"},
+		{"unknown document element", `{"Version":"2012-10-17","Statements":[]}`, "document has unknown elements Statements"},
+		{"empty statement array", `{"Version":"2012-10-17","Statement":[]}`, "document has no statements"},
+		{"Sid with a dash", document(`{"Sid":"read-objects","Effect":"Allow","Action":"s3:GetObject","Resource":"*"}`), `statement 0 has invalid Sid "read-objects"`},
+		{"Sid with a space", document(`{"Sid":"Read objects","Effect":"Allow","Action":"s3:GetObject","Resource":"*"}`), "has invalid Sid"},
+		{"Sid that isn't a string", document(`{"Sid":1,"Effect":"Allow","Action":"s3:GetObject","Resource":"*"}`), "has invalid Sid 1"},
+		{"unknown statement element", document(`{"Effect":"Allow","Actions":"s3:GetObject","Resource":"*"}`), "has unknown elements Actions"},
+		{"missing Effect", document(`{"Action":"s3:GetObject","Resource":"*"}`), "is missing Effect"},
+		{"invalid Effect", document(`{"Effect":"allow","Action":"s3:GetObject","Resource":"*"}`), "has invalid Effect"},
+		{"Action and NotAction", document(`{"Effect":"Allow","Action":"s3:GetObject","NotAction":"s3:PutObject","Resource":"*"}`), "has both Action and NotAction"},
+		{"missing Action", document(`{"Effect":"Allow","Resource":"*"}`), "is missing Action or NotAction"},
+		{"NotAction without Action", document(`{"Effect":"Deny","NotAction":"iam:*","Resource":"*"}`), ""},
+		{"empty Action", document(`{"Effect":"Allow","Action":[],"Resource":"*"}`), "has invalid Action: is empty"},
+		{"action without service", document(`{"Effect":"Allow","Action":"GetObject","Resource":"*"}`), `has invalid Action "GetObject"`},
+		{"Resource and NotResource", document(`{"Effect":"Allow","Action":"s3:GetObject","Resource":"*","NotResource":"arn:aws:s3:::logs/*"}`), "has both Resource and NotResource"},
+		{"missing Resource", document(`{"Effect":"Allow","Action":"s3:GetObject"}`), "is missing Resource or NotResource"},
+		{"principal without Resource", document(`{"Effect":"Allow","Principal":{"Service":"lambda.amazonaws.com"},"Action":"sts:AssumeRole"}`), ""},
+		{"Principal and NotPrincipal", document(`{"Effect":"Deny","Principal":"*","NotPrincipal":{"AWS":"arn:aws:iam::123456789012:root"},"Action":"s3:*","Resource":"*"}`), "has both Principal and NotPrincipal"},
+		{"string Principal", document(`{"Effect":"Allow","Principal":"123456789012","Action":"s3:GetObject","Resource":"*"}`), "has invalid Principal"},
+		{"valid condition", document(`{"Effect":"Deny","Action":"s3:*","Resource":"*","Condition":{"Bool":{"aws:SecureTransport":false},"NumericLessThan":{"s3:TlsVersion":1.2},"ForAnyValue:StringEquals":{"aws:TagKeys":["team"]}}}`), ""},
+		{"unknown condition operator", document(`{"Effect":"Allow","Action":"s3:GetObject","Resource":"*","Condition":{"StringEqual":{"aws:PrincipalTag/team":"data"}}}`), "StringEqual is not a condition operator"},
+		{"condition without keys", document(`{"Effect":"Allow","Action":"s3:GetObject","Resource":"*","Condition":{"StringEquals":{}}}`), "StringEquals has no condition keys"},
+		{"condition without values", document(`{"Effect":"Allow","Action":"s3:GetObject","Resource":"*","Condition":{"StringEquals":{"aws:PrincipalTag/team":[]}}}`), "StringEquals aws:PrincipalTag/team has no values"},
+		{"condition object value", document(`{"Effect":"Allow","Action":"s3:GetObject","Resource":"*","Condition":{"StringEquals":{"aws:PrincipalTag/team":{"team":"data"}}}}`), "StringEquals aws:PrincipalTag/team has an invalid value"},
+		{"condition null value", document(`{"Effect":"Allow","Action":"s3:GetObject","Resource":"*","Condition":{"StringEquals":{"aws:PrincipalTag/team":[null]}}}`), "StringEquals aws:PrincipalTag/team has an invalid value"},
+		{"condition that isn't an object", document(`{"Effect":"Allow","Action":"s3:GetObject","Resource":"*","Condition":["aws:SecureTransport"]}`), "is not an object of condition operators"},
+	}
+
+	for _, tt := range tests {
+		err := ValidatePolicyDocument(tt.policy)
+		if len(tt.err) == 0 {
+			if err != nil {
+				t.Errorf("%s: %s", tt.name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.err) {
+			t.Errorf("%s: got error %v, want %s", tt.name, err, tt.err)
+		}
+	}
+}
+
+func TestGetPolicyStatementWithoutPolicies(t *testing.T) {
+	f := NewFactory().WithAccountID("123456789012").WithRegion("us-east-1")
+
+	if _, err := f.GetPolicyStatement(); err == nil || !strings.Contains(err.Error(), "document has no statements") {
+		t.Errorf("got error %v, want an error for a document without statements", err)
+	}
+
+	policies, err := f.GetPolicyStatements(0)
+	if err != nil || len(policies) != 0 {
+		t.Errorf("got %v and error %v, want no documents", policies, err)
+	}
+}